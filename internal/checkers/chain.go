@@ -0,0 +1,299 @@
+package checkers
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// ChainChecker is implemented by checkers of loggers with a fluent API,
+// such as zerolog, whose fields are added by the calls chained before the
+// logging call rather than by its arguments.
+type ChainChecker interface {
+	Checker
+	// IsChainType reports whether typ holds the fields added by a chain,
+	// e.g. *zerolog.Event. The calls of a chain are the methods of typ.
+	IsChainType(typ types.Type) bool
+	// IsChainEnd reports whether fn ends a chain, logging its fields or
+	// returning a logger holding them, e.g. (*zerolog.Event).Msg.
+	IsChainEnd(fn *types.Func) bool
+	// ChainFields returns the fields added by a call of the chain: the call
+	// itself for a method like Str(key, value), or the entries of a map
+	// literal argument like logrus.Fields{...}. It returns false when the
+	// fields cannot be known, e.g. for a map held in a variable.
+	ChainFields(pass *analysis.Pass, call *ast.CallExpr) ([]ast.Expr, bool)
+	// FieldKeyValue returns the key and the value of a field returned by
+	// ChainFields.
+	FieldKeyValue(pass *analysis.Pass, field ast.Expr) (key, value ast.Expr, ok bool)
+	// FormatFields returns the calls inserted into a chain by fixes to add
+	// fields, from the source of their keys and values, given in pairs.
+	FormatFields(call CallContext, keyValues []string) string
+	// FormatContext returns the call inserted into a chain by fixes to log
+	// the context ctx, e.g. .Ctx(ctx).
+	FormatContext(ctx string) string
+	// SendChain returns the call appended by fixes to a discarded chain of
+	// type typ, e.g. .Send() for *zerolog.Event, or false if discarding
+	// the chain is not reported.
+	SendChain(typ types.Type) (string, bool)
+}
+
+// ExecuteChainChecker checks the call ending a chain, whose fields are
+// checked like the key/value pairs of other logging calls, and reports
+// chains which are started but never ended.
+func ExecuteChainChecker(c ChainChecker, pass *analysis.Pass, call CallContext, cfg Config) {
+	if !c.IsChainEnd(call.Func) {
+		if !isChainLink(c, call.Func) {
+			checkChainSent(pass, c, call)
+		}
+		return
+	}
+
+	root, calls := chainCalls(pass, c, call.Expr)
+	pairs, known := chainKeyValues(pass, c, calls)
+
+	if cfg.RequireStringKey {
+		keyValues := make([]ast.Expr, 0, 2*len(pairs))
+		for _, kv := range pairs {
+			keyValues = append(keyValues, kv.key, kv.value)
+		}
+		c.CheckLoggingKey(pass, keyValues)
+	}
+
+	if cfg.NoPrintfLike && !isFormatFunc(call.Signature) {
+		c.CheckPrintfLikeSpecifier(pass, call.Expr.Args)
+	}
+
+	// Fields may have been added to a chain held in a variable before it
+	// is ended, so only the chains started by the expression, whose fields
+	// are all known, are checked.
+	if _, isCall := root.(*ast.CallExpr); !known || root == nil || !isCall && c.IsChainType(pass.TypesInfo.TypeOf(root)) {
+		return
+	}
+
+	// The chain is judged by its terminal method: call.Func and
+	// call.Signature stay those of Msg or Logger, which the logcall and
+	// withvalues rules name. Only the logger, taken from the receiver of
+	// Expr by the constructor and fromcontext rules and by the traced
+	// loggers, is that of the first call.
+	first := call
+	if len(calls) > 0 {
+		first.Expr = calls[0]
+	}
+	if !requiresTrace(pass, first, cfg) || isTracedLogger(pass, first, cfg) {
+		return
+	}
+
+	if !findContextParam(pass, &call, cfg) {
+		return
+	}
+
+	ins := chainInserter{c: c, call: call, pos: root.End()}
+	for _, kv := range pairs {
+		if _, ok := kv.field.(*ast.KeyValueExpr); ok {
+			ins.firstEntry = kv.field
+			break
+		}
+	}
+	if cfg.ContextLogging && checkChainContextLogging(pass, c, call, cfg, calls, ins) {
+		return
+	}
+
+	checkTraceKeys(pass, c, call, cfg, pairs, ins)
+}
+
+// isChainLink reports whether fn is a method of the chain's types.
+func isChainLink(c ChainChecker, fn *types.Func) bool {
+	if fn == nil {
+		return false
+	}
+	recv := fn.Type().(*types.Signature).Recv()
+	return recv != nil && c.IsChainType(recv.Type())
+}
+
+// chainLinks returns the calls chained before the call ending a chain,
+// innermost first, and the expression the chain is called on. The root of a
+// package function, such as logrus.Info, is the package name, and it is nil
+// for a dot-imported function.
+func chainLinks(pass *analysis.Pass, c ChainChecker, end *ast.CallExpr) (root ast.Expr, links []*ast.CallExpr) {
+	sel, ok := astutil.Unparen(end.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil, nil
+	}
+
+	root = sel.X
+	for {
+		call, ok := astutil.Unparen(root).(*ast.CallExpr)
+		if !ok {
+			break
+		}
+		fn, _ := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !isChainLink(c, fn) {
+			break
+		}
+		links = append([]*ast.CallExpr{call}, links...)
+		root = astutil.Unparen(call.Fun).(*ast.SelectorExpr).X
+	}
+	return root, links
+}
+
+// chainCalls returns the expression the chain ending with end is called on,
+// and the calls of the chain: the links, preceded by the root if it is a
+// call, e.g. log.With() in log.With().Str("key", value).Logger().
+func chainCalls(pass *analysis.Pass, c ChainChecker, end *ast.CallExpr) (ast.Expr, []*ast.CallExpr) {
+	root, links := chainLinks(pass, c, end)
+	if rootCall, ok := root.(*ast.CallExpr); ok {
+		return root, append([]*ast.CallExpr{rootCall}, links...)
+	}
+	return root, links
+}
+
+// chainKeyValues returns the key/value pairs of the fields added by the
+// calls of a chain, and whether they are all known.
+func chainKeyValues(pass *analysis.Pass, c ChainChecker, calls []*ast.CallExpr) ([]keyValue, bool) {
+	var pairs []keyValue
+	known := true
+	for _, link := range calls {
+		fields, ok := c.ChainFields(pass, link)
+		known = known && ok
+		for _, field := range fields {
+			if key, value, ok := c.FieldKeyValue(pass, field); ok {
+				pairs = append(pairs, keyValue{key: key, value: value, field: field})
+			}
+		}
+	}
+	return pairs, known
+}
+
+// checkChainSent reports a chain started by the call which is discarded
+// before it is ended, like log.Info().Str("key", value) without Msg.
+func checkChainSent(pass *analysis.Pass, c ChainChecker, call CallContext) {
+	var expr ast.Expr = call.Expr
+	i := len(call.Stack) - 1
+	for ; i >= 2; i -= 2 {
+		sel, ok := call.Stack[i-1].(*ast.SelectorExpr)
+		if !ok || sel.X != expr {
+			break
+		}
+		next, ok := call.Stack[i-2].(*ast.CallExpr)
+		if !ok || next.Fun != sel {
+			return // method value
+		}
+		fn, _ := typeutil.Callee(pass.TypesInfo, next).(*types.Func)
+		if c.IsChainEnd(fn) || !isChainLink(c, fn) {
+			return
+		}
+		expr = next
+	}
+
+	if _, ok := call.Stack[i-1].(*ast.ExprStmt); !ok {
+		return // the chain is assigned or passed on, and may be ended later
+	}
+	send, ok := c.SendChain(pass.TypesInfo.TypeOf(expr))
+	if !ok {
+		return
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos:      expr.Pos(),
+		End:      expr.End(),
+		Category: DiagnosticCategory,
+		Message:  "logging event is never sent",
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   "Send the logging event",
+			TextEdits: []analysis.TextEdit{{Pos: expr.End(), End: expr.End(), NewText: []byte(send)}},
+		}},
+	})
+}
+
+// checkChainContextLogging is the checkContextLogging of chains: a chain
+// with a call logging the function's ctx, like .Ctx(ctx), is compliant, a
+// chain logging another context is reported with a fix passing ctx, and a
+// chain without any is reported with a fix adding it.
+func checkChainContextLogging(pass *analysis.Pass, c ChainChecker, call CallContext, cfg Config, calls []*ast.CallExpr, ins chainInserter) bool {
+	for _, link := range calls {
+		fn, _ := typeutil.Callee(pass.TypesInfo, link).(*types.Func)
+		if fn == nil {
+			continue
+		}
+		if idx := contextArgIndex(pass, fn.Type().(*types.Signature)); idx >= 0 {
+			checkLoggedContext(pass, call, cfg, link, idx)
+			return true
+		}
+	}
+
+	ctx, edits, err := loggedContextExpr(pass, call)
+	edits = append(edits, insertText(ins.pos, c.FormatContext(ctx)))
+	message := fmt.Sprintf("%s does not log the span of the context, add %s", call.Func.Name(), c.FormatContext("ctx"))
+	reportMissingKey(pass, call, message, "Log the context", edits, err)
+	return true
+}
+
+// isFormatFunc reports whether the logging method formats its variadic
+// arguments, such as Msgf(format string, v ...interface{}).
+func isFormatFunc(sig *types.Signature) bool {
+	params := sig.Params()
+	return sig.Variadic() && params.Len() >= 2 && params.At(params.Len()-2).Name() == "format"
+}
+
+// chainInserter inserts fields into a chain: as entries of the first map
+// literal of the chain if there is one, or as calls chained right after the
+// expression the chain is called on.
+type chainInserter struct {
+	c          ChainChecker
+	call       CallContext
+	pos        token.Pos
+	firstEntry ast.Expr // first map entry, nil if there is none
+}
+
+func (ins chainInserter) insertFields(fields []traceField) []analysis.TextEdit {
+	if ins.firstEntry != nil {
+		entries := make([]string, len(fields))
+		for i, f := range fields {
+			entries[i] = f.entry()
+		}
+		return []analysis.TextEdit{insertText(ins.firstEntry.Pos(), strings.Join(entries, ", ")+", ")}
+	}
+
+	keyValues := make([]string, 0, 2*len(fields))
+	for _, f := range fields {
+		keyValues = append(keyValues, strconv.Quote(f.key), f.value)
+	}
+	return []analysis.TextEdit{insertText(ins.pos, ins.c.FormatFields(ins.call, keyValues))}
+}
+
+func (ins chainInserter) insertAfter(kv keyValue, f traceField) analysis.TextEdit {
+	if _, ok := kv.field.(*ast.KeyValueExpr); ok {
+		return insertText(kv.End(), ", "+f.entry())
+	}
+	return insertText(kv.End(), ins.c.FormatFields(ins.call, []string{strconv.Quote(f.key), f.value}))
+}
+
+// insertBefore inserts f before the map entry kv, or between kv, a call of
+// the chain, and its receiver.
+func (ins chainInserter) insertBefore(kv keyValue, f traceField) analysis.TextEdit {
+	if _, ok := kv.field.(*ast.KeyValueExpr); ok {
+		return insertText(kv.Pos(), f.entry()+", ")
+	}
+	call := kv.field.(*ast.CallExpr)
+	pos := astutil.Unparen(call.Fun).(*ast.SelectorExpr).X.End()
+	return insertText(pos, ins.c.FormatFields(ins.call, []string{strconv.Quote(f.key), f.value}))
+}
+
+// mapLiteralEntries returns the entries of a map literal passed as fields,
+// or false if expr is not a map literal.
+func mapLiteralEntries(pass *analysis.Pass, expr ast.Expr) ([]ast.Expr, bool) {
+	lit, ok := astutil.Unparen(expr).(*ast.CompositeLit)
+	if !ok {
+		return nil, false
+	}
+	if _, ok := pass.TypesInfo.TypeOf(lit).Underlying().(*types.Map); !ok {
+		return nil, false
+	}
+	return lit.Elts, true
+}
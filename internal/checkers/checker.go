@@ -1,26 +1,58 @@
 package checkers
 
 import (
-	"fmt"
 	"go/ast"
 	"go/token"
 	"go/types"
-	"strings"
+
 	"golang.org/x/tools/go/analysis"
-	"bytes"
-	"go/printer"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/george-maroun/tracecheck/internal/keymatch"
+	"github.com/george-maroun/tracecheck/internal/rules"
+	"github.com/george-maroun/tracecheck/internal/sets"
 )
 
 type Config struct {
-	RequireStringKey bool
-	NoPrintfLike     bool
+	RequireStringKey  bool
+	NoPrintfLike      bool
+	RequireTraceFlags bool
+	VerifyTraceValue  bool
+	TraceIDFuncs      sets.StringSet
+	ContextSources    ContextSourceList
+	TracePolicy       sets.StringSet
+	TraceRules        []rules.Ruleset
+	TraceKeys         keymatch.List
+	SpanKeys          keymatch.List
+	TraceFlagKeys     keymatch.List
+	ContextLogging    bool
+	StructLoggers     bool
+	DetachFuncs       sets.StringSet
+	FixTemplate       FixTemplate
+	// CheckerFor returns the checker of a logging function, or nil.
+	CheckerFor func(fn *types.Func) Checker
 }
 
 type CallContext struct {
 	Expr      *ast.CallExpr
 	Func      *types.Func
 	Signature *types.Signature
-	File      *ast.File  
+	File      *ast.File
+	Stack     []ast.Node          // nodes enclosing the call, from File to Expr
+	SSA       ssa.CallInstruction // nil if the call is not found in the SSA form
+	Reported  ReportedEdits       // edits of the fixes reported in the pass
+	Traced    TracedLoggers       // functions returning loggers carrying the trace keys
+
+	// Set by ExecuteChecker before the trace keys are checked. FuncNode is
+	// the innermost function declaration or literal enclosing the call, and
+	// ContextFunc the one, FuncNode or enclosing it, declaring ContextParam.
+	// ContextSource is set when the parameter carries the context rather
+	// than being one, e.g. an *http.Request.
+	FuncNode      ast.Node
+	ContextFunc   ast.Node
+	ContextParam  *types.Var
+	ContextSource *ContextSource
 }
 
 type Checker interface {
@@ -29,19 +61,40 @@ type Checker interface {
 	CheckPrintfLikeSpecifier(pass *analysis.Pass, args []ast.Expr)
 }
 
+// FieldChecker is implemented by checkers of loggers which accept typed
+// fields next to, or instead of, key/value pairs, such as slog.Attr.
+type FieldChecker interface {
+	Checker
+	// FieldKeyValue returns the key and the value of a field argument.
+	FieldKeyValue(pass *analysis.Pass, arg ast.Expr) (key, value ast.Expr, ok bool)
+	// FormatField returns the source of a field inserted into the call by
+	// fixes, from the source of its key and value.
+	FormatField(call CallContext, key, value string) string
+}
+
+// WrapChecker is implemented by checkers of loggers whose values are added
+// by a function wrapping the logger, such as kitlog's log.With, rather than
+// by a method of the logger.
+type WrapChecker interface {
+	Checker
+	// WrapFunc returns the function wrapping the logger of the call in
+	// fixes, e.g. log.With, or false if the file cannot call it.
+	WrapFunc(call CallContext) (string, bool)
+}
+
 func ExecuteChecker(c Checker, pass *analysis.Pass, call CallContext, cfg Config) {
 	params := call.Signature.Params()
 	nparams := params.Len() // variadic => nonzero
 	startIndex := nparams - 1
 
 	lastArg := params.At(nparams - 1)
-	iface, ok := lastArg.Type().(*types.Slice).Elem().(*types.Interface)
-	if !ok || !iface.Empty() {
-		return // final (args) param is not ...interface{}
+	iface, ok := lastArg.Type().(*types.Slice).Elem().Underlying().(*types.Interface)
+	if _, isFieldChecker := c.(FieldChecker); (!ok || !iface.Empty()) && !isFieldChecker {
+		return // final (args) param is not ...interface{}, nor typed fields
 	}
 
 	keyValuesArgs := c.FilterKeyAndValues(pass, call.Expr.Args[startIndex:])
-	
+
 	if len(keyValuesArgs)%2 != 0 {
 		firstArg := keyValuesArgs[0]
 		lastArg := keyValuesArgs[len(keyValuesArgs)-1]
@@ -53,312 +106,152 @@ func ExecuteChecker(c Checker, pass *analysis.Pass, call CallContext, cfg Config
 		})
 	}
 
-	// Return if WithValues is not invoked on NewLogger
-	onNewLogger := isWithValuesCallOnNewLogger(call.File, call.Expr.Pos())
-	if onNewLogger == false {
-		return
+	if cfg.RequireStringKey {
+		c.CheckLoggingKey(pass, keyValuesArgs)
 	}
 
-	funcTakesContext := hasContextParameter(call.File, call.Expr.Pos())
-	if funcTakesContext == false {
-		return
+	if cfg.NoPrintfLike {
+		// Check all args
+		c.CheckPrintfLikeSpecifier(pass, call.Expr.Args)
 	}
 
-	hasTraceId := false
-	for i := 0; i < len(keyValuesArgs); i += 2 {
-		// We use traceId not traceID based on spanId in Google stackdriver stuctured logging
-		// https://cloud.google.com/logging/docs/structured-logging
-		// In the opentelemetry docs it is "TraceId"
-		// https://opentelemetry.io/docs/specs/otel/trace/api/#retrieving-the-traceid-and-spanid
-		// It looks like in the wire format of the w3c spec it might be trace-id
-		// https://www.w3.org/TR/trace-context/#trace-id
-		// This is also how its defined in the OpenTelemetry spec for jsonLogs
-		// https://opentelemetry.io/docs/specs/otel/protocol/file-exporter/#examples
-		// https://opentelemetry.io/docs/specs/otel/logs/
-		arg := keyValuesArgs[i]
-
-		switch v := arg.(type) {
-		case *ast.BasicLit:
-			if strings.Contains(strings.ToLower(v.Value), "trace") {
-				hasTraceId = true
-			}
-		case *ast.Ident:
-			// v.Name contains the name (label) of the variable
-			if strings.Contains(strings.ToLower(v.Name), "trace") {
-				hasTraceId = true
-			}
-		case *ast.SelectorExpr:
-			// Check if the selector (right part) of the qualified identifier contains "trace"
-			if strings.Contains(strings.ToLower(v.Sel.Name), "trace") {
-				hasTraceId = true
-			}
-		}
-		if hasTraceId == true {
-			break
-		}
+	// Trace keys are only required on the calls selected by the trace
+	// policy, or logging through a struct's logger if checked, in functions
+	// which have a context.
+	required := requiresTrace(pass, call, cfg)
+	held := cfg.StructLoggers && heldLogger(pass, call) != nil
+	if !required && !held || isTracedLogger(pass, call, cfg) {
+		return
 	}
 
-	if !hasTraceId {
-		d := &analysis.Diagnostic{
-			Category: DiagnosticCategory,
-			Message:  "missing traceId in logging keys",
-			// Here's where we set the position at which to report this.
-			// We use the position of call argument
-			Pos: call.Expr.Pos(),
-			// N.B we don't set end because it should just apply to the entire line pointed at by pos.
-		}
-
-		// Parse the existing arguments to the log function
-		existingArgs, err := getArgs(call.Expr)
-		if err != nil {
-			// Handle error here. For example:
-			pass.Report(analysis.Diagnostic{
-					Pos:      call.Expr.Pos(),
-					Category: DiagnosticCategory,
-					Message:  fmt.Sprintf("Failed to get arguments: %v", err),
-			})
-   	 return
-		}
-
-		// Add span declaration at the start of the function
-		spanDeclaration := "span := trace.SpanFromContext(ctx)"
-		
-		// Add traceId and spanId to the logging call
-		traceIdAddition := "\"traceId\", span.SpanContext().TraceID().String()"
-		spanIdAddition := "\"spanId\", span.SpanContext().SpanID().String()"
-
-		// Create a new slice to hold the modified arguments
-		newArgs := make([]string, len(existingArgs)+2)
-
-		// Copy the existing arguments before startIndex to the new slice
-		if len(existingArgs) > 0 {
-			copy(newArgs, existingArgs[:startIndex])
-		}
-
-		newArgs[startIndex] = traceIdAddition
-		newArgs[startIndex+1] = spanIdAddition
-
-		// Copy the remaining existing arguments to the new slice
-		if len(existingArgs) > startIndex {
-			copy(newArgs[startIndex+2:], existingArgs[startIndex:])
-		}
-
-		// Construct the new arguments string
-		newArgsStr := strings.Join(newArgs, ", ")
-
-		// Replace the existing logging call with the new one including traceId and spanId
-		newLogCall := fmt.Sprintf(newArgsStr)
-
-		spanInsertPos := findPosOfFuncBody(call.File, call.Expr)
-
-		textEdits := []analysis.TextEdit{
-			// Add the span declaration
-			{
-				Pos: spanInsertPos,
-				End: spanInsertPos,
-				NewText: []byte(spanDeclaration + "\n"),
-			},
-			// Replace the logging call
-			{
-				Pos: findPosOfArgs(call.Expr),
-				End: findEndPosOfArgs(call.Expr),
-				NewText: []byte(newLogCall),
-			},
-		}
-
-		lib := "go.opentelemetry.io/otel/trace"
-		pos, err := getImportPos(call.File, lib)
-		if err == nil && pos != token.NoPos {
-			// Create an edit map to add the trace lib
-			edit := analysis.TextEdit{
-				Pos:     pos,
-				End:     pos,
-				NewText: []byte("\"" + lib + "\"" + "\n"),
-			}
-			// Append edit to textEdits
-			textEdits = append(textEdits, edit)
-		}
-
-		d.SuggestedFixes = []analysis.SuggestedFix{
-			{
-				Message: "Add traceId and spanId to logging keys",
-				// Edit the code to make the fix.
-				TextEdits: textEdits,
-			},
-		}
-		pass.Report(*d)
+	if !findContextParam(pass, &call, cfg) {
+		return
 	}
 
-	if cfg.RequireStringKey {
-		c.CheckLoggingKey(pass, keyValuesArgs)
+	if cfg.ContextLogging && checkContextLogging(pass, call, cfg) {
+		return
 	}
 
-	if cfg.NoPrintfLike {
-		// Check all args
-		c.CheckPrintfLikeSpecifier(pass, call.Expr.Args)
+	pairs := keyValuePairs(pass, c, call, keyValuesArgs, startIndex)
+	if held && checkStructLogger(pass, call, cfg, pairs) || !required {
+		return
 	}
-}
 
-// EnclosingFunc finds the function that encloses the given position.
-// TODO: Refactor the code to avoid revisiting files 
-func enclosingFunc(file *ast.File, pos token.Pos) (fun *ast.FuncDecl) {
-	ast.Inspect(file, func(n ast.Node) bool {
-		switch v := n.(type) {
-		case *ast.FuncDecl:
-			if v.Body != nil && pos >= v.Body.Pos() && pos <= v.Body.End() {
-				fun = v
+	var ins fieldInserter = argsInserter{c: c, call: call, startIndex: startIndex}
+	if wc, ok := c.(WrapChecker); ok {
+		if wrap, ok := wc.WrapFunc(call); ok {
+			if sel, ok := astutil.Unparen(call.Expr.Fun).(*ast.SelectorExpr); ok && pass.TypesInfo.Selections[sel] != nil {
+				ins = wrapInserter{argsInserter: ins.(argsInserter), wrap: wrap, logger: sel.X}
 			}
 		}
-		return true
-	})
-	return
-}
-
-// FindPosOfFuncBody returns the position of the beginning of the function's body
-// that contains the given call expression.
-func findPosOfFuncBody(file *ast.File, call *ast.CallExpr) token.Pos {
-	fun := enclosingFunc(file, call.Pos())
-
-	if fun != nil && fun.Body != nil {
-		insertPos := fun.Body.Rbrace
-		if len(fun.Body.List) > 0 {
-			insertPos = fun.Body.List[0].Pos()
-		}
-		return insertPos
 	}
-	// If fun or fun.Body is nil, return a valid position or handle the error appropriately
-	// For now, we just return token.NoPos
-	return token.NoPos
+	checkTraceKeys(pass, c, call, cfg, pairs, ins)
 }
 
-func hasContextParameter(file *ast.File, pos token.Pos) bool {
-	fun := enclosingFunc(file, pos)
-
-	// Check if the enclosing function declaration has a parameter of type context.Context
-	if fun != nil {
-		for _, param := range fun.Type.Params.List {
-			if selExpr, ok := param.Type.(*ast.SelectorExpr); ok {
-				if xIdent, ok := selExpr.X.(*ast.Ident); ok && xIdent.Name == "context" {
-					if selExpr.Sel.Name == "Context" {
-						return true
-					}
-				}
-			}
+// findContextParam sets the context parameter of the function enclosing
+// the call, and reports whether there is one. A function literal without a
+// context captures the one of the nearest enclosing function which has one.
+func findContextParam(pass *analysis.Pass, call *CallContext, cfg Config) bool {
+	funcs := enclosingFuncs(call.Stack)
+	for _, fun := range funcs {
+		call.ContextParam, call.ContextSource = contextParameter(pass, fun, cfg.ContextSources)
+		if call.ContextParam != nil {
+			call.FuncNode, call.ContextFunc = funcs[0], fun
+			return true
 		}
 	}
 	return false
 }
 
-
-
-func getArgs(call *ast.CallExpr) ([]string, error) {
-	fset := token.NewFileSet()
-	args := make([]string, len(call.Args))
-	
-	for i, arg := range call.Args {
-			// Use the Fprint function from go/printer to convert each expression to a string
-			var buf bytes.Buffer
-			if err := printer.Fprint(&buf, fset, arg); err != nil {
-					return nil, fmt.Errorf("error formatting argument: %w", err)
-			}
-			args[i] = buf.String()
+// enclosingFuncs returns the function declarations and literals of the
+// stack, innermost first.
+func enclosingFuncs(stack []ast.Node) []ast.Node {
+	var funcs []ast.Node
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch stack[i].(type) {
+		case *ast.FuncDecl, *ast.FuncLit:
+			funcs = append(funcs, stack[i])
+		}
 	}
-
-	return args, nil
+	return funcs
 }
 
-
-func findPosOfArgs(call *ast.CallExpr) token.Pos {
-    if len(call.Args) > 0 {
-        return call.Args[0].Pos()
-    }
-    // If there are no arguments, return the position of the opening parenthesis
-    return call.Lparen
+func funcType(fun ast.Node) *ast.FuncType {
+	switch fun := fun.(type) {
+	case *ast.FuncDecl:
+		return fun.Type
+	case *ast.FuncLit:
+		return fun.Type
+	}
+	return nil
 }
 
-func findEndPosOfArgs(call *ast.CallExpr) token.Pos {
-    if len(call.Args) > 0 {
-        return call.Args[len(call.Args)-1].End()
-    }
-    // If there are no arguments, return the position of the opening parenthesis
-    return call.Lparen
+func funcBody(fun ast.Node) *ast.BlockStmt {
+	switch fun := fun.(type) {
+	case *ast.FuncDecl:
+		return fun.Body
+	case *ast.FuncLit:
+		return fun.Body
+	}
+	return nil
 }
 
-
-// findImportStmt finds all import statements in the given AST file.
-// Returns an error if the provided file is nil.
-func findImportStmt(file *ast.File) (importSpecs []*ast.ImportSpec, err error) {
-    if file == nil {
-        return nil, fmt.Errorf("provided file is nil")
-    }
-    
-    for _, decl := range file.Decls {
-        genDecl, ok := decl.(*ast.GenDecl)
-        if !ok {
-            continue
-        }
-
-        // If the declaration is an import declaration
-        if genDecl.Tok == token.IMPORT {
-            for _, spec := range genDecl.Specs {
-                importSpec, ok := spec.(*ast.ImportSpec)
-                if ok {
-                    importSpecs = append(importSpecs, importSpec)
-                }
-            }
-        }
-    }
-    return importSpecs, nil
+func funcSignature(pass *analysis.Pass, fun ast.Node) *types.Signature {
+	switch fun := fun.(type) {
+	case *ast.FuncDecl:
+		if obj, ok := pass.TypesInfo.Defs[fun.Name].(*types.Func); ok {
+			return obj.Type().(*types.Signature)
+		}
+	case *ast.FuncLit:
+		sig, _ := pass.TypesInfo.TypeOf(fun).(*types.Signature)
+		return sig
+	}
+	return nil
 }
 
+// FindPosOfFuncBody returns the position of the beginning of the body of
+// the function declaration or literal.
+func findPosOfFuncBody(fun ast.Node) token.Pos {
+	body := funcBody(fun)
 
-
-func getImportPos(file *ast.File, lib string) (token.Pos, error) {
-	importSpecs, err := findImportStmt(file)
-	if err != nil {
-			return token.NoPos, err
+	if body != nil {
+		insertPos := body.Rbrace
+		if len(body.List) > 0 {
+			insertPos = body.List[0].Pos()
+		}
+		return insertPos
 	}
+	// If the function has no body, e.g. it is implemented in assembly, there
+	// is nowhere to insert at.
+	return token.NoPos
+}
 
-	for _, importSpec := range importSpecs {
-			if strings.Trim(importSpec.Path.Value, `""`) == lib {
-					return token.NoPos, nil
-			}
+// contextParameter returns the parameter of the function declaration or literal which
+// provides its context, preferring in order a parameter of type
+// context.Context, a parameter matching one of the sources, which is then
+// returned too, and a parameter implementing context.Context. The parameter
+// may be unnamed or named "_".
+func contextParameter(pass *analysis.Pass, fun ast.Node, sources ContextSourceList) (*types.Var, *ContextSource) {
+	sig := funcSignature(pass, fun)
+	if sig == nil {
+		return nil, nil
 	}
 
-	if len(importSpecs) > 0 {
-		// Return position after opening bracket of the first import declaration
-		return importSpecs[0].Pos(), nil
+	params := sig.Params()
+	for i := 0; i < params.Len(); i++ {
+		if param := params.At(i); isNamedType(param.Type(), contextPkg, "Context") {
+			return param, nil
+		}
 	}
-
-	return token.NoPos, nil
-}
-
-// TODO: Refactor this function
-func isWithValuesCallOnNewLogger(file *ast.File, pos token.Pos) bool {
-	// Function to find the node at the given position
-	var result bool
-	ast.Inspect(file, func(n ast.Node) bool {
-		if n == nil {
-			return false
+	for i := 0; i < params.Len(); i++ {
+		if source, ok := sources.Match(pass.Pkg, params.At(i).Type()); ok {
+			return params.At(i), &source
 		}
-		// Check if the node corresponds to the given position
-		if n.Pos() <= pos && pos <= n.End() {
-			// Check if the node is a call expression
-			if call, ok := n.(*ast.CallExpr); ok {
-				// Check if the function being called is a selector expression (e.g., obj.Method())
-				if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
-						// Check if the receiver is a call to zapr.NewLogger
-					if call, ok := sel.X.(*ast.CallExpr); ok {
-						if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
-							if sel.Sel.Name == "NewLogger" {
-								result = true
-							}
-						}
-					}
-				}
-			}
+	}
+	for i := 0; i < params.Len(); i++ {
+		if param := params.At(i); isContextType(pass.Pkg, param.Type()) {
+			return param, nil
 		}
-		return true
-	})
-	return result
+	}
+	return nil, nil
 }
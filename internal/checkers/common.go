@@ -6,6 +6,7 @@ import (
 	"go/printer"
 	"go/token"
 	"go/types"
+	"strings"
 	"unicode/utf8"
 
 	"golang.org/x/tools/go/analysis"
@@ -47,3 +48,100 @@ func renderNodeEllipsis(fset *token.FileSet, v interface{}) string {
 	}
 	return s
 }
+
+const (
+	contextPkg   = "context"
+	otelTracePkg = "go.opentelemetry.io/otel/trace"
+)
+
+// isNamedType reports whether typ is the named type pkgPath.name.
+func isNamedType(typ types.Type, pkgPath, name string) bool {
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Name() == name && obj.Pkg() != nil && isPkgPath(obj.Pkg().Path(), pkgPath)
+}
+
+// isFunc reports whether fn is the function pkgPath.name, or the method
+// pkgPath.recv.name when recv is not empty.
+func isFunc(fn *types.Func, pkgPath, recv, name string) bool {
+	if fn == nil || fn.Name() != name || fn.Pkg() == nil || !isPkgPath(fn.Pkg().Path(), pkgPath) {
+		return false
+	}
+
+	sigRecv := fn.Type().(*types.Signature).Recv()
+	if sigRecv == nil {
+		return recv == ""
+	}
+	typ := sigRecv.Type()
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := typ.(*types.Named)
+	return ok && named.Obj().Name() == recv
+}
+
+// funcName returns the full name of fn, as matched by the lists of
+// functions of the configuration, without the vendor directory of its
+// package, e.g. go.opentelemetry.io/otel/trace.ContextWithSpan.
+func funcName(fn *types.Func) string {
+	name := fn.FullName()
+	i := strings.LastIndex(name, "/vendor/")
+	if i < 0 {
+		return name
+	}
+	// Keep the receiver of a method, e.g. (*a/vendor/pkg.T).M.
+	j := strings.LastIndexAny(name[:i], "(*") + 1
+	return name[:j] + name[i+len("/vendor/"):]
+}
+
+// isPkgPath reports whether path is the import path want, possibly vendored.
+func isPkgPath(path, want string) bool {
+	return path == want || strings.HasSuffix(path, "/vendor/"+want)
+}
+
+// isContextType reports whether typ is context.Context, including through
+// an alias, or implements it, e.g. an interface embedding context.Context.
+// pkg is the package being checked, whose imports provide context.Context.
+func isContextType(pkg *types.Package, typ types.Type) bool {
+	if isNamedType(typ, contextPkg, "Context") {
+		return true
+	}
+	iface := contextInterface(pkg)
+	return iface != nil && types.Implements(typ, iface)
+}
+
+// contextInterface returns the context.Context interface imported,
+// directly or not, by pkg, or nil if pkg does not depend on it.
+func contextInterface(pkg *types.Package) *types.Interface {
+	obj := lookupType(pkg, contextPkg, "Context")
+	if obj == nil {
+		return nil
+	}
+	iface, _ := obj.Type().Underlying().(*types.Interface)
+	return iface
+}
+
+// lookupType returns the type pkgPath.name from the packages imported,
+// directly or not, by pkg, or nil if pkg does not depend on it.
+func lookupType(pkg *types.Package, pkgPath, name string) *types.TypeName {
+	seen := map[*types.Package]bool{pkg: true}
+	queue := []*types.Package{pkg}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if isPkgPath(p.Path(), pkgPath) {
+			obj, _ := p.Scope().Lookup(name).(*types.TypeName)
+			return obj
+		}
+		for _, imp := range p.Imports() {
+			if !seen[imp] {
+				seen[imp] = true
+				queue = append(queue, imp)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package checkers
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ssa"
+)
+
+// checkContextLogging checks a call when a handler adds the span of the
+// context passed to context-aware logging methods, such as slog's
+// InfoContext. It reports whether the call has been handled, in which case
+// its trace keys are not checked: a call passing the function's ctx is
+// compliant, a call passing another context is reported with a fix passing
+// ctx, and a call to a method with a context-aware variant, such as Info,
+// is reported with a fix switching to the variant.
+func checkContextLogging(pass *analysis.Pass, call CallContext, cfg Config) bool {
+	if idx := contextArgIndex(pass, call.Signature); idx >= 0 {
+		checkLoggedContext(pass, call, cfg, call.Expr, idx)
+		return true
+	}
+
+	variant := contextVariant(pass, call.Func)
+	if variant == nil {
+		return false
+	}
+
+	ctx, edits, err := loggedContextExpr(pass, call)
+	if ident := funcIdent(call.Expr); ident != nil && len(call.Expr.Args) > 0 {
+		pos := call.Expr.Args[0].Pos()
+		edits = append(edits,
+			analysis.TextEdit{Pos: ident.Pos(), End: ident.End(), NewText: []byte(variant.Name())},
+			analysis.TextEdit{Pos: pos, End: pos, NewText: []byte(ctx + ", ")},
+		)
+	} else {
+		edits = nil
+	}
+	message := fmt.Sprintf("%s does not log the span of the context, use %s", call.Func.Name(), variant.Name())
+	reportMissingKey(pass, call, message, "Use "+variant.Name(), edits, err)
+	return true
+}
+
+// contextArgIndex returns the index of the context parameter of a logging
+// method, or -1 if it has none.
+func contextArgIndex(pass *analysis.Pass, sig *types.Signature) int {
+	params := sig.Params()
+	for i := 0; i < params.Len(); i++ {
+		if isContextType(pass.Pkg, params.At(i).Type()) {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkLoggedContext reports the context argument at idx of expr, the
+// logging call or a call chained before it, unless it is the context of the
+// function enclosing the call, with a fix passing that context instead.
+func checkLoggedContext(pass *analysis.Pass, call CallContext, cfg Config, expr *ast.CallExpr, idx int) {
+	if isFunctionContext(pass, call, cfg, expr, idx) {
+		return
+	}
+
+	arg := expr.Args[idx]
+	ctx, edits, err := loggedContextExpr(pass, call)
+	edits = append(edits, analysis.TextEdit{Pos: arg.Pos(), End: arg.End(), NewText: []byte(ctx)})
+	message := fmt.Sprintf("%s does not log the span of the function's context, pass ctx", call.Func.Name())
+	reportMissingKey(pass, call, message, "Pass "+ctx, edits, err)
+}
+
+// isFunctionContext reports whether the context argument at idx of expr,
+// the logging call or a call chained before it, is the context of the
+// function enclosing the call, or is derived from it.
+func isFunctionContext(pass *analysis.Pass, call CallContext, cfg Config, expr *ast.CallExpr, idx int) bool {
+	arg := expr.Args[idx]
+	if ident, ok := astutil.Unparen(arg).(*ast.Ident); ok && pass.TypesInfo.Uses[ident] == call.ContextParam {
+		return true
+	}
+	if call.SSA == nil {
+		return false
+	}
+
+	v := callArgValue(call.SSA.Parent(), expr, arg)
+	if v == nil {
+		return false
+	}
+	t := &valueTracer{cfg: cfg, fn: call.SSA.Parent(), visited: make(map[ssa.Value]bool)}
+	return t.derivesFromContext(v)
+}
+
+// contextVariant returns the context-aware variant of fn, named after it
+// with a Context suffix, which takes a context followed by the parameters
+// of fn, e.g. (*slog.Logger).InfoContext for (*slog.Logger).Info.
+func contextVariant(pass *analysis.Pass, fn *types.Func) *types.Func {
+	if fn == nil || fn.Pkg() == nil {
+		return nil
+	}
+
+	name := fn.Name() + "Context"
+	sig := fn.Type().(*types.Signature)
+	var variant *types.Func
+	if recv := sig.Recv(); recv != nil {
+		obj, _, _ := types.LookupFieldOrMethod(recv.Type(), true, fn.Pkg(), name)
+		variant, _ = obj.(*types.Func)
+	} else {
+		variant, _ = fn.Pkg().Scope().Lookup(name).(*types.Func)
+	}
+	if variant == nil {
+		return nil
+	}
+
+	params, variantParams := sig.Params(), variant.Type().(*types.Signature).Params()
+	if variantParams.Len() != params.Len()+1 || !isContextType(pass.Pkg, variantParams.At(0).Type()) {
+		return nil
+	}
+	for i := 0; i < params.Len(); i++ {
+		if !types.Identical(params.At(i).Type(), variantParams.At(i+1).Type()) {
+			return nil
+		}
+	}
+	return variant
+}
+
+// loggedContextExpr returns the expression of the context passed by fixes
+// to a context-aware logging call, with the edits naming the context
+// parameter if needed.
+func loggedContextExpr(pass *analysis.Pass, call CallContext) (string, []analysis.TextEdit, error) {
+	param := call.ContextParam
+	name := param.Name()
+	var edits []analysis.TextEdit
+	if name == "" || name == "_" {
+		var err error
+		name, edits, err = contextName(pass, call, findPosOfFuncBody(call.FuncNode))
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	pos := call.Expr.Pos()
+	_, obj := pass.Pkg.Scope().Innermost(pos).LookupParent(name, pos)
+	if obj != nil && obj != param && obj.Pos() >= call.ContextFunc.Pos() && obj.Pos() <= call.ContextFunc.End() {
+		return "", nil, fmt.Errorf("context parameter %s is shadowed at the logging call", name)
+	}
+
+	if call.ContextSource != nil {
+		name += call.ContextSource.Path
+	}
+	return name, edits, nil
+}
+
+// funcIdent returns the identifier naming the function called, e.g. Info
+// in logger.Info(...).
+func funcIdent(call *ast.CallExpr) *ast.Ident {
+	switch fun := astutil.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		return fun
+	case *ast.SelectorExpr:
+		return fun.Sel
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package checkers
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// defaultContextName is the name given by fixes to a context parameter
+// which is unnamed or named "_".
+const defaultContextName = "ctx"
+
+// contextExpr returns the expression of the context of the function
+// enclosing the call at pos, such as ctx or r.Context(), with the edits
+// naming the context parameter if needed.
+func contextExpr(pass *analysis.Pass, call CallContext, pos token.Pos) (string, []analysis.TextEdit, error) {
+	name, edits, err := contextName(pass, call, pos)
+	if err != nil || call.ContextSource == nil {
+		return name, edits, err
+	}
+	return name + call.ContextSource.Path, edits, nil
+}
+
+// contextName returns the name used by fixes to refer to the context
+// parameter of the function enclosing the call. An unnamed or "_" parameter
+// is named defaultContextName, or after the type of its context source, by
+// the returned edits. An error is returned when the parameter cannot be
+// referred to by the span declaration at pos. A variable of the same type
+// redeclaring the parameter before pos, as in a block doing
+// ctx, span := tracer.Start(ctx, "op"), is the context at pos.
+func contextName(pass *analysis.Pass, call CallContext, pos token.Pos) (string, []analysis.TextEdit, error) {
+	param := call.ContextParam
+	switch name := param.Name(); name {
+	case "", "_":
+	default:
+		if obj := shadowingObject(pass, call, name, pos); obj != nil && !types.Identical(obj.Type(), param.Type()) {
+			return "", nil, fmt.Errorf("context parameter %s is shadowed where the span is declared", name)
+		}
+		return name, nil, nil
+	}
+
+	newName := defaultContextName
+	if call.ContextSource != nil {
+		newName = call.ContextSource.DefaultName()
+	}
+	fun := call.ContextFunc
+	if isNameInUse(pass, fun, newName) || shadowingObject(pass, call, newName, pos) != nil {
+		return "", nil, fmt.Errorf("context parameter cannot be named %s, the name is already in use", newName)
+	}
+
+	// Fields are either all named or all unnamed, in which case each field
+	// holds one parameter.
+	params := funcSignature(pass, fun).Params()
+	var edits []analysis.TextEdit
+	idx := 0
+	for _, field := range funcType(fun).Params.List {
+		if len(field.Names) == 0 {
+			name := "_ "
+			if params.At(idx) == param {
+				name = newName + " "
+			}
+			edits = append(edits, analysis.TextEdit{
+				Pos:     field.Type.Pos(),
+				End:     field.Type.Pos(),
+				NewText: []byte(name),
+			})
+			idx++
+			continue
+		}
+		for _, ident := range field.Names {
+			if params.At(idx) == param {
+				edits = append(edits, analysis.TextEdit{
+					Pos:     ident.Pos(),
+					End:     ident.End(),
+					NewText: []byte(newName),
+				})
+			}
+			idx++
+		}
+	}
+	return newName, edits, nil
+}
+
+// shadowingObject returns the object which name refers to at pos, where the
+// span is declared, if it is declared by the function of the context
+// parameter, other than the parameter itself. This happens when a function
+// literal, or a block enclosing it, declares the same name.
+func shadowingObject(pass *analysis.Pass, call CallContext, name string, pos token.Pos) types.Object {
+	scope := pass.TypesInfo.Scopes[funcType(call.FuncNode)]
+	if scope == nil {
+		return nil
+	}
+	_, obj := scope.Innermost(pos).LookupParent(name, pos)
+	if obj != nil && obj != call.ContextParam && obj.Pos() >= call.ContextFunc.Pos() && obj.Pos() <= call.ContextFunc.End() {
+		return obj
+	}
+	return nil
+}
+
+// isNameInUse reports whether declaring a parameter named name would
+// conflict with a declaration of the function's scope, which holds the
+// parameters and the top level declarations of the body, or shadow an
+// object declared outside of the function and used in its body.
+func isNameInUse(pass *analysis.Pass, fun ast.Node, name string) bool {
+	if scope := pass.TypesInfo.Scopes[funcType(fun)]; scope != nil && scope.Lookup(name) != nil {
+		return true
+	}
+
+	inUse := false
+	ast.Inspect(funcBody(fun), func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == name && isDeclaredOutside(pass, fun, ident) {
+			inUse = true
+		}
+		return !inUse
+	})
+	return inUse
+}
+
+// isDeclaredOutside reports whether ident refers to an object declared
+// outside of fun, other than a field or a method which cannot be shadowed.
+func isDeclaredOutside(pass *analysis.Pass, fun ast.Node, ident *ast.Ident) bool {
+	obj := pass.TypesInfo.Uses[ident]
+	switch obj := obj.(type) {
+	case nil:
+		return false
+	case *types.Var:
+		if obj.IsField() {
+			return false
+		}
+	case *types.Func:
+		if obj.Type().(*types.Signature).Recv() != nil {
+			return false
+		}
+	}
+	return obj.Pos() < fun.Pos() || obj.Pos() > fun.End()
+}
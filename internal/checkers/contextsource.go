@@ -0,0 +1,165 @@
+package checkers
+
+import (
+	"errors"
+	"fmt"
+	"go/types"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidContextSource = errors.New("invalid context source format")
+
+// DefaultContextSources are the parameter types of well-known HTTP and RPC
+// handlers which carry the request context.
+var DefaultContextSources = MustParseContextSources(
+	"(*net/http.Request).Context()",
+	"(*github.com/gin-gonic/gin.Context).Request.Context()",
+	"(github.com/labstack/echo/v4.Context).Request().Context()",
+	"(github.com/labstack/echo.Context).Request().Context()",
+	"(google.golang.org/grpc.ServerStream).Context()",
+)
+
+// ContextSource is a parameter type carrying a context, and the selector
+// path returning the context from a parameter of that type, for example
+// "(*net/http.Request).Context()".
+type ContextSource struct {
+	PackageImport string
+	TypeName      string
+	IsPointer     bool
+	Path          string // e.g. ".Request.Context()"
+}
+
+func ParseContextSource(spec string) (ContextSource, error) {
+	end := strings.IndexByte(spec, ')')
+	if !strings.HasPrefix(spec, "(") || end < 0 {
+		return ContextSource{}, ErrInvalidContextSource
+	}
+
+	var s ContextSource
+	receiver := spec[1:end]
+	if strings.HasPrefix(receiver, "*") {
+		s.IsPointer = true
+		receiver = receiver[1:]
+	}
+	typeDotIdx := strings.LastIndexFunc(receiver, func(r rune) bool {
+		return r == '.' || r == '/'
+	})
+	if typeDotIdx <= 0 || receiver[typeDotIdx] == '/' {
+		return ContextSource{}, ErrInvalidContextSource
+	}
+	s.PackageImport, s.TypeName = receiver[:typeDotIdx], receiver[typeDotIdx+1:]
+
+	s.Path = spec[end+1:]
+	if !strings.HasPrefix(s.Path, ".") {
+		return ContextSource{}, ErrInvalidContextSource
+	}
+	for _, sel := range strings.Split(s.Path[1:], ".") {
+		if !isIdentifier(strings.TrimSuffix(sel, "()")) {
+			return ContextSource{}, ErrInvalidContextSource
+		}
+	}
+	return s, nil
+}
+
+func isIdentifier(s string) bool {
+	for i, r := range s {
+		if !unicode.IsLetter(r) && r != '_' && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// Match reports whether typ is the type of the source or, if the source
+// type is an interface, implements it. pkg is the package being checked,
+// whose imports provide the interface.
+func (s ContextSource) Match(pkg *types.Package, typ types.Type) bool {
+	if s.IsPointer {
+		ptr, ok := typ.(*types.Pointer)
+		return ok && isNamedType(ptr.Elem(), s.PackageImport, s.TypeName)
+	}
+	if isNamedType(typ, s.PackageImport, s.TypeName) {
+		return true
+	}
+
+	obj := lookupType(pkg, s.PackageImport, s.TypeName)
+	if obj == nil {
+		return false
+	}
+	iface, ok := obj.Type().Underlying().(*types.Interface)
+	return ok && types.Implements(typ, iface)
+}
+
+// DefaultName returns the name given by fixes to an unnamed parameter of the
+// source type, e.g. "r" for *http.Request.
+func (s ContextSource) DefaultName() string {
+	return strings.ToLower(s.TypeName[:1])
+}
+
+func (s ContextSource) String() string {
+	ptr := ""
+	if s.IsPointer {
+		ptr = "*"
+	}
+	return "(" + ptr + s.PackageImport + "." + s.TypeName + ")" + s.Path
+}
+
+// ContextSourceList is a list of context sources, in order of preference.
+type ContextSourceList []ContextSource
+
+func ParseContextSources(specs ...string) (ContextSourceList, error) {
+	l := make(ContextSourceList, 0, len(specs))
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+
+		s, err := ParseContextSource(spec)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", err, spec)
+		}
+		l = append(l, s)
+	}
+	return l, nil
+}
+
+// MustParseContextSources is like ParseContextSources but panics on invalid
+// specs, for use in package level defaults.
+func MustParseContextSources(specs ...string) ContextSourceList {
+	l, err := ParseContextSources(specs...)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// Match returns the first source matching typ.
+func (l ContextSourceList) Match(pkg *types.Package, typ types.Type) (ContextSource, bool) {
+	for _, s := range l {
+		if s.Match(pkg, typ) {
+			return s, true
+		}
+	}
+	return ContextSource{}, false
+}
+
+// Set implements flag.Value interface.
+func (l *ContextSourceList) Set(v string) error {
+	parsed, err := ParseContextSources(strings.Split(v, ",")...)
+	if err != nil {
+		return err
+	}
+	*l = parsed
+	return nil
+}
+
+// String implements flag.Value interface
+func (l ContextSourceList) String() string {
+	specs := make([]string, len(l))
+	for i, s := range l {
+		specs[i] = s.String()
+	}
+	return strings.Join(specs, ",")
+}
@@ -0,0 +1,246 @@
+package checkers
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// TracedLoggerFact is exported for a function returning a logger which
+// carries the trace keys, such as:
+//
+//	func newRequestLogger(ctx context.Context) logr.Logger {
+//		span := trace.SpanFromContext(ctx)
+//		return logr.FromContextOrDiscard(ctx).WithValues("traceId", ..., "spanId", ...)
+//	}
+//
+// The calls on the loggers it returns are compliant, in other packages too.
+type TracedLoggerFact struct {
+	// Param is the index of the logger parameter to which the function adds
+	// the trace keys, or -1 if the logger does not come from a parameter.
+	Param int
+}
+
+func (*TracedLoggerFact) AFact() {}
+
+func (f *TracedLoggerFact) String() string {
+	if f.Param < 0 {
+		return "tracedLogger"
+	}
+	return fmt.Sprintf("tracedLogger(param %d)", f.Param)
+}
+
+// TracedLoggers holds the facts of the functions returning loggers which
+// carry the trace keys, in the package and its dependencies.
+type TracedLoggers map[*types.Func]*TracedLoggerFact
+
+// ExportTracedLoggerFacts exports a TracedLoggerFact for each function of
+// the package whose return statements all return, at the same position, a
+// logger carrying the trace keys: one returned by a logging call adding
+// them, such as WithValues, or by a function with the fact. It returns the
+// facts of the package and of its dependencies.
+func ExportTracedLoggerFacts(pass *analysis.Pass, cfg Config) TracedLoggers {
+	type funcDecl struct {
+		fn   *types.Func
+		decl *ast.FuncDecl
+		file *ast.File
+	}
+	var decls []funcDecl
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.FuncDecl)
+			if !ok || decl.Body == nil || decl.Type.Results == nil {
+				continue
+			}
+			if fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func); ok {
+				decls = append(decls, funcDecl{fn: fn, decl: decl, file: file})
+			}
+		}
+	}
+
+	// The functions may return the loggers of each other, so facts are
+	// exported until no more can be.
+	for changed := true; changed; {
+		changed = false
+		for _, d := range decls {
+			if pass.ImportObjectFact(d.fn, new(TracedLoggerFact)) {
+				continue
+			}
+			t := tracedReturns{pass: pass, cfg: cfg, file: d.file, decl: d.decl}
+			if fact, ok := t.fact(); ok {
+				pass.ExportObjectFact(d.fn, fact)
+				changed = true
+			}
+		}
+	}
+
+	traced := make(TracedLoggers)
+	for _, f := range pass.AllObjectFacts() {
+		fn, ok := f.Object.(*types.Func)
+		if fact, isTraced := f.Fact.(*TracedLoggerFact); ok && isTraced {
+			traced[fn] = fact
+		}
+	}
+	return traced
+}
+
+// tracedReturns finds the traced loggers returned by a function declaration.
+type tracedReturns struct {
+	pass *analysis.Pass
+	cfg  Config
+	file *ast.File
+	decl *ast.FuncDecl
+}
+
+func (t tracedReturns) fact() (*TracedLoggerFact, bool) {
+	var returns []*ast.ReturnStmt
+	ast.Inspect(t.decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			returns = append(returns, n)
+		}
+		return true
+	})
+
+	nresults := t.pass.TypesInfo.Defs[t.decl.Name].Type().(*types.Signature).Results().Len()
+	for i := 0; i < nresults; i++ {
+		if param, ok := t.tracedResult(returns, i); ok {
+			return &TracedLoggerFact{Param: param}, true
+		}
+	}
+	return nil, false
+}
+
+// tracedResult reports whether every return statement returns a traced
+// logger, or nil, as result i, and the parameter they all add the trace
+// keys to, if any.
+func (t tracedReturns) tracedResult(returns []*ast.ReturnStmt, i int) (int, bool) {
+	param, traced := -1, false
+	for _, ret := range returns {
+		if len(ret.Results) <= i {
+			return -1, false // naked return, or return of a call's results
+		}
+		result := ret.Results[i]
+		if t.pass.TypesInfo.Types[result].IsNil() {
+			continue
+		}
+		p, ok := t.tracedLogger(result)
+		if !ok || traced && p != param {
+			return -1, false
+		}
+		param, traced = p, true
+	}
+	return param, traced
+}
+
+// tracedLogger reports whether expr is a logger carrying the trace keys,
+// following the assignments to local variables, and the index of the
+// parameter it derives from, or -1.
+func (t tracedReturns) tracedLogger(expr ast.Expr) (int, bool) {
+	r := &keyResolver{pass: t.pass, body: t.decl.Body}
+	for depth := 0; depth < maxResolveDepth && expr != nil; depth++ {
+		switch x := astutil.Unparen(expr).(type) {
+		case *ast.Ident:
+			v, ok := t.pass.TypesInfo.Uses[x].(*types.Var)
+			if !ok {
+				return -1, false
+			}
+			expr = r.varAssignment(v, x.Pos())
+		case *ast.CallExpr:
+			return t.tracedCall(x)
+		default:
+			return -1, false
+		}
+	}
+	return -1, false
+}
+
+func (t tracedReturns) tracedCall(expr *ast.CallExpr) (int, bool) {
+	fn, _ := typeutil.Callee(t.pass.TypesInfo, expr).(*types.Func)
+	if fn == nil {
+		return -1, false
+	}
+
+	fact := new(TracedLoggerFact)
+	if t.pass.ImportObjectFact(fn, fact) {
+		if fact.Param < 0 || fact.Param >= len(expr.Args) {
+			return -1, true
+		}
+		return t.paramIndex(expr.Args[fact.Param]), true
+	}
+
+	c := t.cfg.CheckerFor(fn)
+	if c == nil {
+		return -1, false
+	}
+	call := CallContext{
+		Expr:      expr,
+		Func:      fn,
+		Signature: fn.Type().(*types.Signature),
+		File:      t.file,
+		Stack:     []ast.Node{t.file, t.decl},
+	}
+	logger, ok := carriesTrace(t.pass, c, call, t.cfg)
+	if !ok {
+		return -1, false
+	}
+	return t.paramIndex(logger), true
+}
+
+// paramIndex returns the index of the parameter of the function which expr
+// refers to, or -1.
+func (t tracedReturns) paramIndex(expr ast.Expr) int {
+	ident, ok := astutil.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return -1
+	}
+	params := t.pass.TypesInfo.Defs[t.decl.Name].Type().(*types.Signature).Params()
+	for i := 0; i < params.Len(); i++ {
+		if t.pass.TypesInfo.Uses[ident] == params.At(i) {
+			return i
+		}
+	}
+	return -1
+}
+
+// carriesTrace reports whether the logging call adds the trace keys, and
+// returns the logger it adds them to.
+func carriesTrace(pass *analysis.Pass, c Checker, call CallContext, cfg Config) (ast.Expr, bool) {
+	var logger ast.Expr
+	var pairs []keyValue
+	if cc, ok := c.(ChainChecker); ok {
+		if !cc.IsChainEnd(call.Func) {
+			return nil, false
+		}
+		root, calls := chainCalls(pass, cc, call.Expr)
+		var known bool
+		if pairs, known = chainKeyValues(pass, cc, calls); !known {
+			return nil, false
+		}
+		logger = root
+	} else {
+		if !call.Signature.Variadic() || call.Expr.Ellipsis.IsValid() {
+			return nil, false
+		}
+		startIndex := call.Signature.Params().Len() - 1
+		if len(call.Expr.Args) < startIndex {
+			return nil, false
+		}
+		keyValuesArgs := c.FilterKeyAndValues(pass, call.Expr.Args[startIndex:])
+		pairs = keyValuePairs(pass, c, call, keyValuesArgs, startIndex)
+		logger = loggerExpr(pass, call)
+	}
+
+	funcs := enclosingFuncs(call.Stack)
+	trace, hasSpanId, hasTraceFlags := findTraceKeys(pass, funcBody(funcs[len(funcs)-1]), cfg, pairs)
+	if trace == nil || !hasSpanId || cfg.RequireTraceFlags && !hasTraceFlags {
+		return nil, false
+	}
+	return logger, true
+}
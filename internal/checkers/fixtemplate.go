@@ -0,0 +1,296 @@
+package checkers
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"go/types"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidFixTemplate = errors.New("invalid fix template")
+
+// FixTemplate is the code inserted by the fixes of missing trace keys for a
+// tracing library. The code is Go source with placeholders: {ctx} for the
+// context of the function, {span} for the variable declared by Declaration,
+// and {name}. for the qualifier of the import named name, e.g.
+//
+//	{span} := {trace}.SpanFromContext({ctx})
+//
+// The values use {span} when the template declares it, and {ctx} otherwise.
+type FixTemplate struct {
+	Name        string
+	Imports     []FixImport
+	Declaration string // e.g. "{span} := {trace}.SpanFromContext({ctx})", or ""
+	SpanType    string // type of the variables reused as {span}, e.g. "go.opentelemetry.io/otel/trace.Span"
+
+	// The keys inserted, or "" for the first literal key accepted by the
+	// checks. The values are string expressions, and TraceFlags is "" when
+	// the library has no trace flags.
+	TraceKey, SpanKey, TraceFlagsKey string
+	TraceID, SpanID, TraceFlags      string
+}
+
+// FixImport is a package used by the code of a fix template.
+type FixImport struct {
+	Path string
+	// Names are tried in order when the file does not import the package.
+	// The first is the name of its placeholder and of the package.
+	Names []string
+}
+
+func (imp FixImport) placeholder() string {
+	return "{" + imp.Names[0] + "}."
+}
+
+// Fix template names.
+const (
+	FixTemplateOpenTelemetry = "otel"
+	FixTemplateOpenCensus    = "opencensus"
+	FixTemplateDatadog       = "datadog"
+	FixTemplateHelper        = "helper"
+)
+
+// FixTemplateNames lists the valid fix template names.
+var FixTemplateNames = []string{FixTemplateOpenTelemetry, FixTemplateOpenCensus, FixTemplateDatadog, FixTemplateHelper}
+
+// FixTemplates are the built-in fix templates, by name.
+var FixTemplates = map[string]FixTemplate{
+	FixTemplateOpenTelemetry: {
+		Name:        FixTemplateOpenTelemetry,
+		Imports:     []FixImport{{Path: otelTracePkg, Names: []string{"trace", "oteltrace", "otelTrace"}}},
+		Declaration: "{span} := {trace}.SpanFromContext({ctx})",
+		SpanType:    otelTracePkg + ".Span",
+		TraceID:     "{span}.SpanContext().TraceID().String()",
+		SpanID:      "{span}.SpanContext().SpanID().String()",
+		TraceFlags:  "{span}.SpanContext().TraceFlags().String()",
+	},
+	FixTemplateOpenCensus: {
+		Name: FixTemplateOpenCensus,
+		Imports: []FixImport{
+			{Path: "go.opencensus.io/trace", Names: []string{"trace", "octrace", "ocTrace"}},
+			{Path: "fmt", Names: []string{"fmt"}},
+		},
+		Declaration: "{span} := {trace}.FromContext({ctx})", // nil spans have an empty SpanContext
+		SpanType:    "*go.opencensus.io/trace.Span",
+		TraceID:     "{span}.SpanContext().TraceID.String()",
+		SpanID:      "{span}.SpanContext().SpanID.String()",
+		TraceFlags:  `{fmt}.Sprintf("%02x", {span}.SpanContext().TraceOptions)`,
+	},
+	// https://docs.datadoghq.com/tracing/other_telemetry/connect_logs_and_traces/go/
+	FixTemplateDatadog: {
+		Name: FixTemplateDatadog,
+		Imports: []FixImport{
+			{Path: "gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer", Names: []string{"tracer", "ddtracer", "ddTracer"}},
+			{Path: "strconv", Names: []string{"strconv"}},
+		},
+		Declaration: "{span}, _ := {tracer}.SpanFromContext({ctx})", // a no-op span if ctx has none
+		SpanType:    "gopkg.in/DataDog/dd-trace-go.v1/ddtrace.Span",
+		TraceKey:    "dd.trace_id",
+		SpanKey:     "dd.span_id",
+		TraceID:     "{strconv}.FormatUint({span}.Context().TraceID(), 10)",
+		SpanID:      "{strconv}.FormatUint({span}.Context().SpanID(), 10)",
+	},
+	// The helper template calls the functions of an in-house package, whose
+	// import path is to be set, e.g. tracelog.TraceID(ctx).
+	FixTemplateHelper: {
+		Name:       FixTemplateHelper,
+		Imports:    []FixImport{{Names: []string{"tracelog"}}},
+		TraceID:    "{tracelog}.TraceID({ctx})",
+		SpanID:     "{tracelog}.SpanID({ctx})",
+		TraceFlags: "{tracelog}.TraceFlags({ctx})",
+	},
+}
+
+// placeholderRe matches the placeholders of the code of fix templates.
+var placeholderRe = regexp.MustCompile(`\{(\w+)\}(\.?)`)
+
+// ParseFixTemplate returns the template overriding base with lines of the
+// form "field: value", for example:
+//
+//	import: example.com/platform/tracelog
+//	traceKey: trace_id
+//	traceId: {tracelog}.TraceID({ctx})
+//
+// The fields are import, whose value is the import path followed by the
+// names tried for it, replacing the import of the same first name,
+// declaration, span, traceKey, spanKey, traceFlagsKey, traceId, spanId and
+// traceFlags.
+func ParseFixTemplate(base FixTemplate, lines []string) (FixTemplate, error) {
+	t := base
+	t.Imports = append([]FixImport{}, base.Imports...)
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		field, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return FixTemplate{}, fmt.Errorf("%w at line %d: missing ':'", ErrInvalidFixTemplate, i+1)
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(field) {
+		case "import":
+			fields := strings.Fields(value)
+			if len(fields) == 0 {
+				return FixTemplate{}, fmt.Errorf("%w at line %d: missing import path", ErrInvalidFixTemplate, i+1)
+			}
+			imp := FixImport{Path: fields[0], Names: fields[1:]}
+			if len(imp.Names) == 0 {
+				imp.Names = []string{path.Base(imp.Path)}
+			}
+			for _, name := range imp.Names {
+				if !isIdentifier(name) {
+					return FixTemplate{}, fmt.Errorf("%w at line %d: invalid import name %q", ErrInvalidFixTemplate, i+1, name)
+				}
+			}
+			t.Imports = setImport(t.Imports, imp)
+		case "declaration":
+			t.Declaration = value
+		case "span":
+			t.SpanType = value
+		case "traceKey":
+			t.TraceKey = value
+		case "spanKey":
+			t.SpanKey = value
+		case "traceFlagsKey":
+			t.TraceFlagsKey = value
+		case "traceId":
+			t.TraceID = value
+		case "spanId":
+			t.SpanID = value
+		case "traceFlags":
+			t.TraceFlags = value
+		default:
+			return FixTemplate{}, fmt.Errorf("%w at line %d: unknown field %q", ErrInvalidFixTemplate, i+1, field)
+		}
+	}
+	return t, nil
+}
+
+// ParseFixTemplateFile is like ParseFixTemplate, for the lines of r.
+func ParseFixTemplateFile(base FixTemplate, r io.Reader) (FixTemplate, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return FixTemplate{}, err
+	}
+	return ParseFixTemplate(base, lines)
+}
+
+// setImport replaces the import of imports with the same first name as imp,
+// or appends imp.
+func setImport(imports []FixImport, imp FixImport) []FixImport {
+	for i := range imports {
+		if imports[i].Names[0] == imp.Names[0] {
+			imports[i] = imp
+			return imports
+		}
+	}
+	return append(imports, imp)
+}
+
+// Validate reports whether the code of the template only uses known
+// placeholders, and the imports it uses have a path.
+func (t FixTemplate) Validate() error {
+	if t.TraceID == "" || t.SpanID == "" {
+		return fmt.Errorf("%w: the %s template has no traceId or spanId value", ErrInvalidFixTemplate, t.Name)
+	}
+	if t.Declaration != "" && !strings.Contains(t.Declaration, "{span}") {
+		return fmt.Errorf("%w: the declaration of the %s template does not declare {span}", ErrInvalidFixTemplate, t.Name)
+	}
+	if t.SpanType != "" {
+		if _, _, _, ok := parseTypeName(t.SpanType); !ok {
+			return fmt.Errorf("%w: invalid span type %q", ErrInvalidFixTemplate, t.SpanType)
+		}
+	}
+
+	// The values refer to the span when it is declared, and else to the
+	// context, which the declaration is the only one to refer to then.
+	valueVar, otherVar := "ctx", "span"
+	if t.Declaration != "" {
+		valueVar, otherVar = "span", "ctx"
+	}
+	if err := t.validateCode(t.Declaration, "ctx", "span"); err != nil {
+		return err
+	}
+	for _, value := range []string{t.TraceID, t.SpanID, t.TraceFlags} {
+		if err := t.validateCode(value, valueVar); err != nil {
+			return err
+		}
+		if strings.Contains(value, "{"+otherVar+"}") {
+			return fmt.Errorf("%w: the values of the %s template use {%s}, use {%s}", ErrInvalidFixTemplate, t.Name, otherVar, valueVar)
+		}
+	}
+	return nil
+}
+
+func (t FixTemplate) validateCode(code string, vars ...string) error {
+	for _, m := range placeholderRe.FindAllStringSubmatch(code, -1) {
+		name, isQualifier := m[1], m[2] != ""
+		imp, isImport := t.importNamed(name)
+		switch {
+		case isImport && isQualifier:
+			if imp.Path == "" {
+				return fmt.Errorf("%w: the import path of {%s} is not set", ErrInvalidFixTemplate, name)
+			}
+		case isImport:
+			return fmt.Errorf("%w: {%s} is not followed by a selector", ErrInvalidFixTemplate, name)
+		case !containsString(vars, name):
+			return fmt.Errorf("%w: unknown placeholder {%s} in %q", ErrInvalidFixTemplate, name, code)
+		}
+	}
+	return nil
+}
+
+func (t FixTemplate) importNamed(name string) (FixImport, bool) {
+	for _, imp := range t.Imports {
+		if imp.Names[0] == name {
+			return imp, true
+		}
+	}
+	return FixImport{}, false
+}
+
+// matchSpanType reports whether typ is the span type of the template.
+func (t FixTemplate) matchSpanType(typ types.Type) bool {
+	pointer, pkgPath, name, ok := parseTypeName(t.SpanType)
+	if !ok {
+		return false
+	}
+	if pointer {
+		ptr, isPtr := typ.(*types.Pointer)
+		if !isPtr {
+			return false
+		}
+		typ = ptr.Elem()
+	}
+	return isNamedType(typ, pkgPath, name)
+}
+
+// parseTypeName parses a type such as "*go.opencensus.io/trace.Span".
+func parseTypeName(s string) (pointer bool, pkgPath, name string, ok bool) {
+	pointer = strings.HasPrefix(s, "*")
+	s = strings.TrimPrefix(s, "*")
+	i := strings.LastIndexByte(s, '.')
+	if i <= 0 || i < strings.LastIndexByte(s, '/') || !isIdentifier(s[i+1:]) {
+		return false, "", "", false
+	}
+	return pointer, s[:i], s[i+1:], true
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
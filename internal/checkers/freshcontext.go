@@ -0,0 +1,110 @@
+package checkers
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// DefaultDetachFuncs lists the functions detaching from a context on
+// purpose, whose fresh context arguments are accepted by CheckFreshContext.
+var DefaultDetachFuncs = []string{
+	"context.WithoutCancel",
+	"go.opentelemetry.io/otel/trace.ContextWithSpan",
+	"go.opentelemetry.io/otel/trace.ContextWithSpanContext",
+	"go.opentelemetry.io/otel/trace.ContextWithRemoteSpanContext",
+}
+
+// CheckFreshContext reports a call to context.Background or context.TODO in
+// a function which has a context: the fresh context has no span, so the
+// logs of the functions it is passed to miss the trace ids of the function's
+// context. The fix substitutes the function's context for it.
+//
+// Fresh contexts created in one of cfg.DetachFuncs, or passed to one, are
+// accepted as they detach from the function's context on purpose, e.g.
+// trace.ContextWithSpan(context.Background(), span) in a goroutine outliving
+// the request.
+func CheckFreshContext(pass *analysis.Pass, call CallContext, cfg Config) {
+	if !isFunc(call.Func, contextPkg, "", "Background") && !isFunc(call.Func, contextPkg, "", "TODO") {
+		return
+	}
+	if !findContextParam(pass, &call, cfg) || isDetached(pass, call, cfg) {
+		return
+	}
+
+	fresh := "context." + call.Func.Name() + "()"
+	ctx, edits, err := loggedContextExpr(pass, call)
+	edits = append(edits, analysis.TextEdit{Pos: call.Expr.Pos(), End: call.Expr.End(), NewText: []byte(ctx)})
+
+	var message string
+	if callee := contextCallee(pass, call); callee != nil {
+		message = fmt.Sprintf("%s passed to %s drops the span of the function's context", fresh, callee.Name())
+	} else {
+		message = fmt.Sprintf("%s drops the span of the function's context", fresh)
+	}
+	if err == nil {
+		message += ", use " + ctx
+	}
+	reportMissingKey(pass, call, message, "Use "+ctx, edits, err)
+}
+
+// isDetached reports whether the fresh context of the call is created in
+// one of the detach functions, or passed to one.
+func isDetached(pass *analysis.Pass, call CallContext, cfg Config) bool {
+	for _, fun := range enclosingFuncs(call.Stack) {
+		decl, ok := fun.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		if fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func); ok && cfg.DetachFuncs.Has(funcName(fn)) {
+			return true
+		}
+	}
+
+	parent, ok := parentCall(call)
+	if !ok {
+		return false
+	}
+	fn, _ := typeutil.Callee(pass.TypesInfo, parent).(*types.Func)
+	return fn != nil && cfg.DetachFuncs.Has(funcName(fn))
+}
+
+// contextCallee returns the function to which the fresh context of the call
+// is passed as first argument, if its first parameter is a context.
+func contextCallee(pass *analysis.Pass, call CallContext) *types.Func {
+	parent, ok := parentCall(call)
+	if !ok || len(parent.Args) == 0 || astutil.Unparen(parent.Args[0]) != call.Expr {
+		return nil
+	}
+	fn, _ := typeutil.Callee(pass.TypesInfo, parent).(*types.Func)
+	if fn == nil {
+		return nil
+	}
+	params := fn.Type().(*types.Signature).Params()
+	if params.Len() == 0 || !isNamedType(params.At(0).Type(), contextPkg, "Context") {
+		return nil
+	}
+	return fn
+}
+
+// parentCall returns the call to which the call is passed as an argument.
+func parentCall(call CallContext) (*ast.CallExpr, bool) {
+	for i := len(call.Stack) - 2; i >= 0; i-- {
+		switch n := call.Stack[i].(type) {
+		case *ast.ParenExpr:
+			continue
+		case *ast.CallExpr:
+			for _, arg := range n.Args {
+				if astutil.Unparen(arg) == call.Expr {
+					return n, true
+				}
+			}
+		}
+		return nil, false
+	}
+	return nil, false
+}
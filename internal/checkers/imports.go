@@ -0,0 +1,199 @@
+package checkers
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// importQualifier returns the qualifier, such as "trace.", by which the code
+// at pos refers to the package imp, with the edits importing it if needed.
+// An existing import is used unless its name is shadowed at pos, and the
+// package is otherwise imported under the first of imp.Names which is free
+// in the file and at pos.
+func importQualifier(pass *analysis.Pass, file *ast.File, pos token.Pos, imp FixImport) (string, []analysis.TextEdit, error) {
+	for _, spec := range file.Imports {
+		if p, err := strconv.Unquote(spec.Path.Value); err != nil || p != imp.Path {
+			continue
+		}
+		name := imp.Names[0]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		} else if pkgName, ok := pass.TypesInfo.Implicits[spec].(*types.PkgName); ok {
+			name = pkgName.Name()
+		}
+		switch name {
+		case "_":
+			continue
+		case ".":
+			return "", nil, nil
+		}
+		if isPkgNameAt(pass, name, pos) {
+			return name + ".", nil, nil
+		}
+	}
+
+	for _, name := range imp.Names {
+		if isNameFree(pass, file, name, pos) {
+			alias := ""
+			if name != imp.Names[0] {
+				alias = name
+			}
+			return name + ".", addImportEdits(pass.Fset, file, alias, imp.Path), nil
+		}
+	}
+	return "", nil, fmt.Errorf("the names %s of %s are already in use", strings.Join(imp.Names, ", "), imp.Path)
+}
+
+// isPkgNameAt reports whether name refers at pos to an imported package.
+func isPkgNameAt(pass *analysis.Pass, name string, pos token.Pos) bool {
+	_, obj := pass.Pkg.Scope().Innermost(pos).LookupParent(name, pos)
+	_, ok := obj.(*types.PkgName)
+	return ok
+}
+
+// isNameFree reports whether an import of the file can be named name and
+// referred to at pos: no import of the file, declaration of the package or
+// declaration in scope at pos has the name.
+func isNameFree(pass *analysis.Pass, file *ast.File, name string, pos token.Pos) bool {
+	if scope := pass.TypesInfo.Scopes[file]; scope != nil && scope.Lookup(name) != nil {
+		return false
+	}
+	if pass.Pkg.Scope().Lookup(name) != nil {
+		return false
+	}
+	_, obj := pass.Pkg.Scope().Innermost(pos).LookupParent(name, pos)
+	return obj == nil || obj.Parent() == types.Universe
+}
+
+// addImportEdits returns the edits importing path under name, or under its
+// own name if name is empty, in the style of astutil.AddNamedImport: the
+// import is added to a group of imports of the same kind, standard library
+// or not, at its sorted position, or in a new group, or in a new import
+// declaration if the file has none or only unparenthesized ones.
+//
+// The blank line separating a new group is an edit of its own, the same for
+// every import added to the group, so that the fixes of a file adding
+// different imports to the group create it once: ReportedEdits leaves it out
+// of the fixes after the first. The imports of the new group are inserted
+// at the start of the line of the parenthesis closing the declaration, or
+// opening it for a group of the standard library, apart from those added to
+// the existing groups.
+func addImportEdits(fset *token.FileSet, file *ast.File, name, path string) []analysis.TextEdit {
+	spec := strconv.Quote(path)
+	if name != "" {
+		spec = name + " " + spec
+	}
+
+	var decl, last *ast.GenDecl
+	for _, d := range file.Decls {
+		d, ok := d.(*ast.GenDecl)
+		if !ok || d.Tok != token.IMPORT {
+			continue
+		}
+		last = d
+		if decl == nil && d.Lparen.IsValid() {
+			decl = d
+		}
+	}
+	switch {
+	case last == nil:
+		return []analysis.TextEdit{insertText(file.Name.End(), "\n\nimport "+spec)}
+	case decl == nil || len(decl.Specs) == 0:
+		return []analysis.TextEdit{insertText(last.End(), "\nimport "+spec)}
+	}
+
+	groups := importGroups(fset, decl)
+	std := isStdImport(path)
+	var group []*ast.ImportSpec
+	for _, g := range groups {
+		if isStdGroup(g) == std {
+			group = g
+		}
+	}
+	if group == nil {
+		return newGroupEdits(fset, decl, groups, spec, std)
+	}
+
+	for _, s := range group {
+		if p, err := strconv.Unquote(s.Path.Value); err == nil && p > path {
+			return []analysis.TextEdit{insertText(s.Pos(), spec+"\n\t")}
+		}
+	}
+	return []analysis.TextEdit{insertText(specEnd(group), "\n\t"+spec)}
+}
+
+// newGroupEdits returns the edits adding spec to a new group of decl,
+// before its groups for the standard library, after them otherwise.
+func newGroupEdits(fset *token.FileSet, decl *ast.GenDecl, groups [][]*ast.ImportSpec, spec string, std bool) []analysis.TextEdit {
+	file := fset.File(decl.Pos())
+	first, last := groups[0][0], groups[len(groups)-1]
+	if std {
+		if file.Line(first.Pos()) == file.Line(decl.Lparen) {
+			return []analysis.TextEdit{insertText(first.Pos(), spec+"\n\n\t")}
+		}
+		return []analysis.TextEdit{
+			insertText(decl.Lparen+1, "\n\t"+spec),
+			insertText(file.LineStart(file.Line(first.Pos())), "\n"),
+		}
+	}
+
+	if file.Line(decl.Rparen) == file.Line(specEnd(last)) {
+		return []analysis.TextEdit{insertText(specEnd(last), "\n\n\t"+spec)}
+	}
+	lineStart := file.LineStart(file.Line(decl.Rparen))
+	return []analysis.TextEdit{
+		insertText(lineStart, "\n"),
+		insertText(lineStart, "\t"+spec+"\n"),
+	}
+}
+
+// importGroups returns the imports of a parenthesized declaration, grouped
+// as separated by blank lines.
+func importGroups(fset *token.FileSet, decl *ast.GenDecl) [][]*ast.ImportSpec {
+	var groups [][]*ast.ImportSpec
+	prevLine := 0
+	for i, s := range decl.Specs {
+		spec := s.(*ast.ImportSpec)
+		line := fset.Position(spec.Pos()).Line
+		if i == 0 || line > prevLine+1 {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], spec)
+		prevLine = fset.Position(spec.End()).Line
+	}
+	return groups
+}
+
+// specEnd returns the end of the last import of the group, after its
+// comment if any.
+func specEnd(group []*ast.ImportSpec) token.Pos {
+	last := group[len(group)-1]
+	if last.Comment != nil {
+		return last.Comment.End()
+	}
+	return last.End()
+}
+
+// isStdGroup reports whether the group only imports standard library
+// packages.
+func isStdGroup(group []*ast.ImportSpec) bool {
+	for _, s := range group {
+		if p, err := strconv.Unquote(s.Path.Value); err == nil && !isStdImport(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// isStdImport reports whether path is a standard library package, whose
+// first element has no dot, as told apart by goimports.
+func isStdImport(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
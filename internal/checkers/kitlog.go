@@ -0,0 +1,19 @@
+package checkers
+
+const kitlogPkg = "github.com/go-kit/log"
+
+type Kitlog struct {
+	General
+}
+
+// WrapFunc returns log.With, with which fixes wrap the logger of a Log call
+// to add the missing trace keys, or false if the file does not import kitlog.
+func (k Kitlog) WrapFunc(call CallContext) (string, bool) {
+	name, ok := importName(call.File, kitlogPkg)
+	if !ok {
+		return "", false
+	}
+	return name + ".With", true
+}
+
+var _ WrapChecker = (*Kitlog)(nil)
@@ -0,0 +1,223 @@
+package checkers
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// isTracedLogger reports whether the logger of the call already carries the
+// trace keys, in which case the call needs none: the logger is returned by
+// a logging call adding them, such as WithValues, by a function with a
+// TracedLoggerFact, or is derived from such a logger. The logger is
+// followed through the SSA form of the function, so that it is only traced
+// if it is on every path reaching the call, e.g.:
+//
+//	log := base.WithValues("traceId", ..., "spanId", ...)
+//	if debug {
+//		log = base // the call below is reported
+//	}
+//	log.Info("message")
+func isTracedLogger(pass *analysis.Pass, call CallContext, cfg Config) bool {
+	if call.SSA == nil {
+		origin := loggerOrigin(pass, call)
+		return origin != nil && call.Traced[origin] != nil
+	}
+
+	f := &loggerFlow{pass: pass, cfg: cfg, traced: call.Traced, visiting: make(map[ssa.Value]bool)}
+	logger := f.loggerValue(call.SSA.Parent(), call.Expr)
+	return logger != nil && f.isTraced(logger)
+}
+
+// loggerFlow follows the SSA values of loggers within a function.
+type loggerFlow struct {
+	pass     *analysis.Pass
+	cfg      Config
+	traced   TracedLoggers
+	visiting map[ssa.Value]bool
+}
+
+// isTraced reports whether the logger v carries the trace keys. Cycles, such
+// as a logger reassigned in a loop, are traced if their other values are.
+func (f *loggerFlow) isTraced(v ssa.Value) bool {
+	if f.visiting[v] {
+		return true
+	}
+	f.visiting[v] = true
+	defer delete(f.visiting, v)
+	return f.isTracedValue(v)
+}
+
+func (f *loggerFlow) isTracedValue(v ssa.Value) bool {
+	switch v := v.(type) {
+	case *ssa.Call:
+		return f.isTracedCall(v)
+	case *ssa.Extract:
+		call, ok := v.Tuple.(*ssa.Call)
+		return ok && f.isTracedCall(call)
+	case *ssa.Phi:
+		for _, edge := range v.Edges {
+			if !f.isTraced(edge) {
+				return false
+			}
+		}
+		return len(v.Edges) > 0
+	case *ssa.UnOp:
+		return v.Op == token.MUL && f.isTracedAddr(v.X)
+	case *ssa.Alloc:
+		return f.isTracedAddr(v)
+	case *ssa.ChangeType:
+		return f.isTraced(v.X)
+	case *ssa.MakeInterface:
+		return f.isTraced(v.X)
+	case *ssa.ChangeInterface:
+		return f.isTraced(v.X)
+	}
+	return false
+}
+
+// isTracedAddr reports whether every logger stored at addr, a local variable
+// or a field of a local struct, carries the trace keys.
+func (f *loggerFlow) isTracedAddr(addr ssa.Value) bool {
+	// A variable captured by a closure is stored by the enclosing function.
+	if fv, ok := addr.(*ssa.FreeVar); ok {
+		addr = closureBinding(fv)
+	}
+
+	var addrs []ssa.Value
+	switch addr := addr.(type) {
+	case *ssa.Alloc:
+		addrs = []ssa.Value{addr}
+	case *ssa.FieldAddr:
+		// The field is stored through other FieldAddr of the same struct.
+		for _, ref := range *addr.X.Referrers() {
+			if fa, ok := ref.(*ssa.FieldAddr); ok && fa.X == addr.X && fa.Field == addr.Field {
+				addrs = append(addrs, fa)
+			}
+		}
+	default:
+		return false // e.g. a global, or a pointer parameter
+	}
+
+	stored := false
+	for _, a := range addrs {
+		for _, ref := range *a.Referrers() {
+			store, ok := ref.(*ssa.Store)
+			if !ok || store.Addr != a {
+				continue
+			}
+			if !f.isTraced(store.Val) {
+				return false
+			}
+			stored = true
+		}
+	}
+	return stored
+}
+
+// isTracedCall reports whether the logger returned by call carries the trace
+// keys.
+func (f *loggerFlow) isTracedCall(call *ssa.Call) bool {
+	if callee := call.Common().StaticCallee(); callee != nil {
+		if fn, ok := callee.Object().(*types.Func); ok && f.traced[fn] != nil {
+			return true
+		}
+	}
+
+	file, stack := f.callStack(call.Pos())
+	if stack == nil {
+		return false
+	}
+	expr := stack[len(stack)-1].(*ast.CallExpr)
+	fn, _ := typeutil.Callee(f.pass.TypesInfo, expr).(*types.Func)
+	if fn == nil {
+		return false
+	}
+	c := f.cfg.CheckerFor(fn)
+	if c == nil {
+		return false
+	}
+	ctx := CallContext{Expr: expr, Func: fn, Signature: fn.Type().(*types.Signature), File: file, Stack: stack}
+	if _, ok := carriesTrace(f.pass, c, ctx, f.cfg); ok {
+		return true
+	}
+
+	// A logger derived from a traced logger carries its trace keys.
+	if cc, ok := c.(ChainChecker); ok {
+		_, calls := chainCalls(f.pass, cc, expr)
+		if len(calls) == 0 {
+			return false
+		}
+		expr = calls[0]
+	}
+	logger := f.loggerValue(call.Parent(), expr)
+	return logger != nil && f.isTraced(logger)
+}
+
+// loggerValue returns the SSA value of the logger of the call in fn: the
+// receiver of a method, or the logger wrapped by a function such as
+// log.With(logger, ...).
+func (f *loggerFlow) loggerValue(fn *ssa.Function, expr *ast.CallExpr) ssa.Value {
+	instr := ssaCallAt(fn, expr.Lparen)
+	if instr == nil {
+		return nil
+	}
+
+	common := instr.Common()
+	if common.IsInvoke() {
+		return common.Value
+	}
+	if common.Signature().Recv() != nil || loggerExpr(f.pass, CallContext{Expr: expr}) != nil {
+		if len(common.Args) > 0 {
+			return common.Args[0]
+		}
+	}
+	return nil
+}
+
+// callStack returns the file and the nodes enclosing the call whose opening
+// parenthesis is at lparen, from the file to the call.
+func (f *loggerFlow) callStack(lparen token.Pos) (*ast.File, []ast.Node) {
+	for _, file := range f.pass.Files {
+		if lparen < file.Pos() || lparen >= file.End() {
+			continue
+		}
+		path, _ := astutil.PathEnclosingInterval(file, lparen, lparen+1)
+		if len(path) == 0 {
+			return nil, nil
+		}
+		call, ok := path[0].(*ast.CallExpr)
+		if !ok || call.Lparen != lparen {
+			return nil, nil
+		}
+		stack := make([]ast.Node, len(path))
+		for i, n := range path {
+			stack[len(path)-1-i] = n
+		}
+		return file, stack
+	}
+	return nil, nil
+}
+
+// ssaCallAt returns the call of fn, or of the functions it encloses, whose
+// opening parenthesis is at lparen.
+func ssaCallAt(fn *ssa.Function, lparen token.Pos) ssa.CallInstruction {
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			if call, ok := instr.(ssa.CallInstruction); ok && call.Common().Pos() == lparen {
+				return call
+			}
+		}
+	}
+	for _, anon := range fn.AnonFuncs {
+		if call := ssaCallAt(anon, lparen); call != nil {
+			return call
+		}
+	}
+	return nil
+}
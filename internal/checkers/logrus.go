@@ -0,0 +1,94 @@
+package checkers
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const logrusPkg = "github.com/sirupsen/logrus"
+
+type Logrus struct {
+	General
+}
+
+// IsChainType reports whether typ is an entry, which holds the fields added
+// by WithField and WithFields.
+func (l Logrus) IsChainType(typ types.Type) bool {
+	ptr, ok := typ.(*types.Pointer)
+	return ok && isNamedType(ptr.Elem(), logrusPkg, "Entry")
+}
+
+// IsChainEnd reports whether fn logs an entry, or logs with the standard
+// logger or a Logger, which are chains without fields.
+func (l Logrus) IsChainEnd(fn *types.Func) bool {
+	if fn == nil || fn.Pkg() == nil || !isPkgPath(fn.Pkg().Path(), logrusPkg) {
+		return false
+	}
+	level := strings.TrimSuffix(strings.TrimSuffix(fn.Name(), "ln"), "f")
+	switch level {
+	case "Trace", "Debug", "Info", "Print", "Warn", "Warning", "Error", "Fatal", "Panic", "Log":
+		return fn.Type().(*types.Signature).Variadic()
+	}
+	return false
+}
+
+// ChainFields returns the WithField calls, and the entries of a map literal
+// passed to WithFields.
+func (l Logrus) ChainFields(pass *analysis.Pass, call *ast.CallExpr) ([]ast.Expr, bool) {
+	fn, _ := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if fn == nil || fn.Pkg() == nil || !isPkgPath(fn.Pkg().Path(), logrusPkg) {
+		return nil, true
+	}
+	switch fn.Name() {
+	case "WithField":
+		return []ast.Expr{call}, true
+	case "WithFields":
+		return mapLiteralEntries(pass, call.Args[0])
+	}
+	return nil, true
+}
+
+func (l Logrus) FieldKeyValue(_ *analysis.Pass, field ast.Expr) (key, value ast.Expr, ok bool) {
+	switch field := field.(type) {
+	case *ast.KeyValueExpr:
+		return field.Key, field.Value, true
+	case *ast.CallExpr:
+		return field.Args[0], field.Args[1], true
+	}
+	return nil, nil, false
+}
+
+// FormatFields returns a WithField call for a single field, and a
+// WithFields call otherwise, or WithField calls if the file does not import
+// logrus.
+func (l Logrus) FormatFields(call CallContext, keyValues []string) string {
+	name, ok := importName(call.File, logrusPkg)
+	if len(keyValues) > 2 && ok {
+		entries := make([]string, 0, len(keyValues)/2)
+		for i := 0; i+1 < len(keyValues); i += 2 {
+			entries = append(entries, keyValues[i]+": "+keyValues[i+1])
+		}
+		return ".WithFields(" + name + ".Fields{" + strings.Join(entries, ", ") + "})"
+	}
+
+	var b strings.Builder
+	for i := 0; i+1 < len(keyValues); i += 2 {
+		b.WriteString(".WithField(" + keyValues[i] + ", " + keyValues[i+1] + ")")
+	}
+	return b.String()
+}
+
+func (l Logrus) FormatContext(ctx string) string {
+	return ".WithContext(" + ctx + ")"
+}
+
+// SendChain reports false, as an entry is a logger rather than an event.
+func (l Logrus) SendChain(types.Type) (string, bool) {
+	return "", false
+}
+
+var _ ChainChecker = (*Logrus)(nil)
@@ -0,0 +1,411 @@
+package checkers
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// provenance classifies where the value logged for a trace key comes from.
+type provenance int
+
+const (
+	fromSpanInContext provenance = iota // the span of the enclosing function's context
+	fromLiteral                         // a constant
+	fromOtherContext                    // the span of a context not derived from the function's context
+	fromUnknown                         // anything else, e.g. an unrelated id generator
+)
+
+// checkTraceValue reports a traceId value which is not derived from
+// trace.SpanFromContext(ctx).SpanContext().TraceID(), or from one of the
+// configured helper functions, using the enclosing function's ctx.
+func checkTraceValue(pass *analysis.Pass, call CallContext, cfg Config, trace keyValue) {
+	if call.SSA == nil {
+		return
+	}
+	var v ssa.Value
+	if field, ok := trace.field.(*ast.CallExpr); ok {
+		v = callArgValue(call.SSA.Parent(), field, trace.value)
+	} else if trace.field == nil {
+		v = variadicArgValue(call, trace.value)
+	}
+	if v == nil {
+		return
+	}
+	value := trace.value
+
+	t := &valueTracer{cfg: cfg, fn: call.SSA.Parent(), visited: make(map[ssa.Value]bool)}
+	var message string
+	switch t.traceID(v) {
+	case fromLiteral:
+		message = "traceId value is a constant, not the trace id of the span in ctx"
+	case fromOtherContext:
+		message = "traceId value is taken from a context other than the function's ctx"
+	case fromUnknown:
+		message = "traceId value is not derived from trace.SpanFromContext(ctx)"
+	default:
+		return
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:      value.Pos(),
+		End:      value.End(),
+		Category: DiagnosticCategory,
+		Message:  message,
+	})
+}
+
+// variadicArgValue returns the SSA value of arg, one of the variadic
+// arguments of the call. Variadic arguments are stored into an array
+// allocated for the call, so we look for the store at the index of arg.
+func variadicArgValue(call CallContext, arg ast.Expr) ssa.Value {
+	startIndex := call.Signature.Params().Len() - 1
+	idx := -1
+	for i, a := range call.Expr.Args {
+		if a == arg {
+			idx = i - startIndex
+		}
+	}
+	args := call.SSA.Common().Args
+	if idx < 0 || len(args) == 0 {
+		return nil
+	}
+
+	slice, ok := args[len(args)-1].(*ssa.Slice)
+	if !ok {
+		return nil
+	}
+	alloc, ok := slice.X.(*ssa.Alloc)
+	if !ok {
+		return nil
+	}
+	for _, ref := range *alloc.Referrers() {
+		indexAddr, ok := ref.(*ssa.IndexAddr)
+		if !ok {
+			continue
+		}
+		if c, ok := indexAddr.Index.(*ssa.Const); !ok || c.Int64() != int64(idx) {
+			continue
+		}
+		for _, ref := range *indexAddr.Referrers() {
+			if store, ok := ref.(*ssa.Store); ok && store.Addr == indexAddr {
+				return store.Val
+			}
+		}
+	}
+	return nil
+}
+
+// callArgValue returns the SSA value of arg, one of the arguments of a
+// call made by fn, such as a call building a typed field. The variadic
+// arguments of a call are not supported, see variadicArgValue.
+func callArgValue(fn *ssa.Function, call *ast.CallExpr, arg ast.Expr) ssa.Value {
+	idx := -1
+	for i, a := range call.Args {
+		if a == arg {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return nil
+	}
+
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			c, ok := instr.(ssa.CallInstruction)
+			if !ok || c.Common().Pos() != call.Lparen {
+				continue
+			}
+			sig := c.Common().Signature()
+			if sig.Variadic() && idx >= sig.Params().Len()-1 {
+				return nil
+			}
+			// The receiver of a static method call is its first argument.
+			args := c.Common().Args
+			return args[len(args)-sig.Params().Len()+idx]
+		}
+	}
+	return nil
+}
+
+// valueTracer follows SSA values back to the span they were read from.
+type valueTracer struct {
+	cfg     Config
+	fn      *ssa.Function // function containing the logging call
+	visited map[ssa.Value]bool
+}
+
+func (t *valueTracer) traceID(v ssa.Value) provenance {
+	if stored, ok := storedValues(v); ok {
+		return t.merge(v, stored, t.traceID)
+	}
+
+	switch v := v.(type) {
+	case *ssa.Const:
+		return fromLiteral
+	case *ssa.MakeInterface:
+		return t.traceID(v.X)
+	case *ssa.Phi:
+		return t.merge(v, v.Edges, t.traceID)
+	case *ssa.Call:
+		fn := calleeFunc(v.Call)
+		switch {
+		case isFunc(fn, otelTracePkg, "TraceID", "String"):
+			return t.traceID(v.Call.Args[0])
+		case isFunc(fn, otelTracePkg, "SpanContext", "TraceID"):
+			return t.spanContext(v.Call.Args[0])
+		case fn != nil && t.cfg.TraceIDFuncs.Has(fn.FullName()):
+			return t.contextArg(v.Call)
+		}
+	}
+	return fromUnknown
+}
+
+func (t *valueTracer) spanContext(v ssa.Value) provenance {
+	if stored, ok := storedValues(v); ok {
+		return t.merge(v, stored, t.spanContext)
+	}
+
+	switch v := v.(type) {
+	case *ssa.Phi:
+		return t.merge(v, v.Edges, t.spanContext)
+	case *ssa.Call:
+		fn := calleeFunc(v.Call)
+		switch {
+		case isFunc(fn, otelTracePkg, "Span", "SpanContext"):
+			return t.span(v.Call.Value)
+		case isFunc(fn, otelTracePkg, "", "SpanContextFromContext"):
+			return t.context(v.Call.Args[0])
+		}
+	}
+	return fromUnknown
+}
+
+func (t *valueTracer) span(v ssa.Value) provenance {
+	if stored, ok := storedValues(v); ok {
+		return t.merge(v, stored, t.span)
+	}
+
+	switch v := v.(type) {
+	case *ssa.Phi:
+		return t.merge(v, v.Edges, t.span)
+	case *ssa.Call:
+		if isFunc(calleeFunc(v.Call), otelTracePkg, "", "SpanFromContext") {
+			return t.context(v.Call.Args[0])
+		}
+	case *ssa.Extract:
+		// ctx, span := tracer.Start(ctx, "op")
+		call, ok := v.Tuple.(*ssa.Call)
+		if ok && v.Index == 1 && isFunc(calleeFunc(call.Call), otelTracePkg, "Tracer", "Start") {
+			return t.context(call.Call.Args[0])
+		}
+	}
+	return fromUnknown
+}
+
+// contextArg checks the first context argument of a call to a helper function.
+func (t *valueTracer) contextArg(call ssa.CallCommon) provenance {
+	for _, arg := range call.Args {
+		if isContextType(t.pkg(), arg.Type()) {
+			return t.context(arg)
+		}
+	}
+	return fromUnknown
+}
+
+func (t *valueTracer) context(v ssa.Value) provenance {
+	if t.derivesFromContext(v) {
+		return fromSpanInContext
+	}
+	return fromOtherContext
+}
+
+// derivesFromContext reports whether the context v is a context parameter of
+// the function containing the logging call, or of a function enclosing it,
+// or is derived from one, e.g. by context.WithTimeout or tracer.Start.
+func (t *valueTracer) derivesFromContext(v ssa.Value) bool {
+	if t.visited[v] {
+		return true // assume loops preserve the context, the other edges decide
+	}
+	t.visited[v] = true
+
+	if stored, ok := storedValues(v); ok {
+		for _, s := range stored {
+			if !t.derivesFromContext(s) {
+				return false
+			}
+		}
+		return len(stored) > 0
+	}
+
+	switch v := v.(type) {
+	case *ssa.Parameter:
+		return isContextType(t.pkg(), v.Type()) && t.enclosesCall(v.Parent())
+	case *ssa.FreeVar:
+		binding := closureBinding(v)
+		return binding != nil && t.derivesFromContext(binding)
+	case *ssa.Phi:
+		for _, edge := range v.Edges {
+			if !t.derivesFromContext(edge) {
+				return false
+			}
+		}
+		return true
+	case *ssa.MakeInterface:
+		return t.derivesFromContext(v.X)
+	case *ssa.ChangeInterface:
+		return t.derivesFromContext(v.X)
+	case *ssa.Extract:
+		return t.derivesFromContext(v.Tuple)
+	case *ssa.Call:
+		// A call returning a new context derives it from any context
+		// argument, or from the parameter carrying the context, as in r.Context().
+		if v.Call.IsInvoke() && t.derivesFromContext(v.Call.Value) || t.derivesFromSource(v) {
+			return true
+		}
+		for _, arg := range v.Call.Args {
+			if isContextType(t.pkg(), arg.Type()) && t.derivesFromContext(arg) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// derivesFromSource reports whether v is read from a parameter matching
+// one of the context sources, through fields and method calls.
+func (t *valueTracer) derivesFromSource(v ssa.Value) bool {
+	if stored, ok := storedValues(v); ok {
+		for _, s := range stored {
+			if !t.derivesFromSource(s) {
+				return false
+			}
+		}
+		return len(stored) > 0
+	}
+
+	switch v := v.(type) {
+	case *ssa.Parameter:
+		_, ok := t.cfg.ContextSources.Match(t.pkg(), v.Type())
+		return ok && t.enclosesCall(v.Parent())
+	case *ssa.FreeVar:
+		binding := closureBinding(v)
+		return binding != nil && t.derivesFromSource(binding)
+	case *ssa.UnOp:
+		return v.Op == token.MUL && t.derivesFromSource(v.X)
+	case *ssa.FieldAddr:
+		return t.derivesFromSource(v.X)
+	case *ssa.Field:
+		return t.derivesFromSource(v.X)
+	case *ssa.Call:
+		if v.Call.IsInvoke() {
+			return len(v.Call.Args) == 0 && t.derivesFromSource(v.Call.Value)
+		}
+		if fn := v.Call.StaticCallee(); fn != nil && fn.Signature.Recv() != nil && len(v.Call.Args) == 1 {
+			return t.derivesFromSource(v.Call.Args[0])
+		}
+	}
+	return false
+}
+
+// pkg returns the package containing the logging call.
+func (t *valueTracer) pkg() *types.Package {
+	return t.fn.Pkg.Pkg
+}
+
+// enclosesCall reports whether fn is the function containing the logging
+// call, or one of the functions it is nested in.
+func (t *valueTracer) enclosesCall(fn *ssa.Function) bool {
+	for f := t.fn; f != nil; f = f.Parent() {
+		if f == fn {
+			return true
+		}
+	}
+	return false
+}
+
+// merge combines the provenance of the values that v may take, such as
+// the edges of a phi node, reporting the first one which is not derived
+// from the span in ctx.
+func (t *valueTracer) merge(v ssa.Value, values []ssa.Value, trace func(ssa.Value) provenance) provenance {
+	if t.visited[v] {
+		return fromSpanInContext // assume loops preserve the span, the other values decide
+	}
+	t.visited[v] = true
+
+	if len(values) == 0 {
+		return fromUnknown
+	}
+	for _, v := range values {
+		if p := trace(v); p != fromSpanInContext {
+			return p
+		}
+	}
+	return fromSpanInContext
+}
+
+// storedValues returns the values stored into the variable loaded by v, if
+// v loads a local variable. Variables captured by function literals, or
+// whose address is taken, live in memory rather than in SSA registers.
+func storedValues(v ssa.Value) ([]ssa.Value, bool) {
+	load, ok := v.(*ssa.UnOp)
+	if !ok || load.Op != token.MUL {
+		return nil, false
+	}
+
+	addr := load.X
+	if fv, ok := addr.(*ssa.FreeVar); ok {
+		addr = closureBinding(fv)
+	}
+	alloc, ok := addr.(*ssa.Alloc)
+	if !ok {
+		return nil, false
+	}
+
+	var stored []ssa.Value
+	for _, ref := range *alloc.Referrers() {
+		if store, ok := ref.(*ssa.Store); ok && store.Addr == alloc {
+			stored = append(stored, store.Val)
+		}
+	}
+	return stored, true
+}
+
+// closureBinding returns the value captured by the free variable v of a
+// function literal, as bound by the enclosing function.
+func closureBinding(v *ssa.FreeVar) ssa.Value {
+	fn := v.Parent()
+	idx := -1
+	for i, fv := range fn.FreeVars {
+		if fv == v {
+			idx = i
+		}
+	}
+	if idx < 0 || fn.Parent() == nil {
+		return nil
+	}
+
+	for _, b := range fn.Parent().Blocks {
+		for _, instr := range b.Instrs {
+			if closure, ok := instr.(*ssa.MakeClosure); ok && closure.Fn == fn {
+				return closure.Bindings[idx]
+			}
+		}
+	}
+	return nil
+}
+
+// calleeFunc returns the function or interface method called, if known.
+func calleeFunc(call ssa.CallCommon) *types.Func {
+	if call.IsInvoke() {
+		return call.Method
+	}
+	if callee := call.StaticCallee(); callee != nil {
+		fn, _ := callee.Object().(*types.Func)
+		return fn
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package checkers
+
+import (
+	"go/ast"
+	"go/types"
+	"path"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const slogPkg = "log/slog"
+
+// slogAttrFuncs are the functions returning a slog.Attr from a key and a value.
+var slogAttrFuncs = []string{"String", "Int", "Int64", "Uint64", "Float64", "Bool", "Time", "Duration", "Any"}
+
+type Slog struct {
+	General
+}
+
+func (s Slog) FilterKeyAndValues(pass *analysis.Pass, keyAndValues []ast.Expr) []ast.Expr {
+	filtered := make([]ast.Expr, 0, len(keyAndValues))
+	for _, arg := range keyAndValues {
+		// An slog.Attr, including an slog.Group, is used as is and
+		// consumes a single argument.
+		if isSlogAttr(pass.TypesInfo.TypeOf(arg)) {
+			continue
+		}
+
+		filtered = append(filtered, arg)
+	}
+
+	return filtered
+}
+
+// FieldKeyValue returns the key and value of an attribute built by one of
+// the slog.String-like functions, or by an slog.Attr literal. Groups are
+// ignored since their keys are nested under the group name.
+func (s Slog) FieldKeyValue(pass *analysis.Pass, arg ast.Expr) (key, value ast.Expr, ok bool) {
+	switch arg := astutil.Unparen(arg).(type) {
+	case *ast.CallExpr:
+		fn, _ := typeutil.Callee(pass.TypesInfo, arg).(*types.Func)
+		for _, name := range slogAttrFuncs {
+			if isFunc(fn, slogPkg, "", name) && len(arg.Args) == 2 {
+				return arg.Args[0], arg.Args[1], true
+			}
+		}
+	case *ast.CompositeLit:
+		if !isSlogAttr(pass.TypesInfo.TypeOf(arg)) {
+			break
+		}
+		for _, elt := range arg.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			ident, ok := kv.Key.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			switch ident.Name {
+			case "Key":
+				key = kv.Value
+			case "Value":
+				value = kv.Value
+			}
+		}
+		return key, value, key != nil
+	}
+	return nil, nil, false
+}
+
+// FormatField returns an slog.String attribute, or a plain key/value pair
+// if the file does not import log/slog and the call accepts one. Calls
+// taking attributes only, such as LogAttrs, get an attribute of the
+// package imported by FieldImport.
+func (s Slog) FormatField(call CallContext, key, value string) string {
+	name, ok := importName(call.File, slogPkg)
+	if !ok {
+		if !takesAttrs(call) {
+			return key + ", " + value
+		}
+		name = path.Base(slogPkg)
+	}
+	return name + ".String(" + key + ", " + value + ")"
+}
+
+// FieldImport returns log/slog if the attributes formatted for the call
+// need it to be imported.
+func (s Slog) FieldImport(call CallContext) (string, bool) {
+	if _, ok := importName(call.File, slogPkg); ok || !takesAttrs(call) {
+		return "", false
+	}
+	return slogPkg, true
+}
+
+// takesAttrs reports whether the variadic arguments of the call are
+// slog.Attr values, as for LogAttrs.
+func takesAttrs(call CallContext) bool {
+	params := call.Signature.Params()
+	args, ok := params.At(params.Len() - 1).Type().(*types.Slice)
+	return ok && isSlogAttr(args.Elem())
+}
+
+func isSlogAttr(typ types.Type) bool {
+	return typ != nil && isNamedType(typ, slogPkg, "Attr")
+}
+
+// importName returns the name under which the file imports importPath.
+func importName(file *ast.File, importPath string) (string, bool) {
+	for _, spec := range file.Imports {
+		if p, err := strconv.Unquote(spec.Path.Value); err != nil || p != importPath {
+			continue
+		}
+		if spec.Name == nil {
+			return path.Base(importPath), true
+		}
+		if spec.Name.Name == "_" || spec.Name.Name == "." {
+			return "", false
+		}
+		return spec.Name.Name, true
+	}
+	return "", false
+}
+
+var _ FieldChecker = (*Slog)(nil)
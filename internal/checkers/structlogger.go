@@ -0,0 +1,220 @@
+package checkers
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// structLoggerNames are the names tried in order for the logger declared
+// by the fix of checkStructLogger.
+var structLoggerNames = []string{"log", "logger", "reqLogger"}
+
+// heldLogger returns the logger of the call when it is a field of a struct,
+// such as s.log, or nil.
+func heldLogger(pass *analysis.Pass, call CallContext) *ast.SelectorExpr {
+	sel, ok := astutil.Unparen(loggerExpr(pass, call)).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	if s := pass.TypesInfo.Selections[sel]; s == nil || s.Kind() != types.FieldVal {
+		return nil
+	}
+	if fieldRoot(pass, sel) == nil {
+		return nil
+	}
+	return sel
+}
+
+// fieldRoot returns the variable holding the struct of a field selection,
+// e.g. s for s.deps.log, or nil if it is not a variable.
+func fieldRoot(pass *analysis.Pass, sel *ast.SelectorExpr) *types.Var {
+	switch x := astutil.Unparen(sel.X).(type) {
+	case *ast.Ident:
+		v, _ := pass.TypesInfo.Uses[x].(*types.Var)
+		return v
+	case *ast.SelectorExpr:
+		if s := pass.TypesInfo.Selections[x]; s != nil && s.Kind() == types.FieldVal {
+			return fieldRoot(pass, x)
+		}
+	}
+	return nil
+}
+
+// checkStructLogger checks a call logging through a logger held by a
+// struct, such as s.log in a method of s, which is usually created once
+// without the trace keys. It reports whether the call has been handled: a
+// call without a traceId is reported, with a fix declaring a logger which
+// carries the trace keys at the start of the function, e.g.
+//
+//	log := s.log.WithValues("traceId", ..., "spanId", ...)
+//
+// and replacing the uses of s.log in the function with it.
+func checkStructLogger(pass *analysis.Pass, call CallContext, cfg Config, pairs []keyValue) bool {
+	funcs := enclosingFuncs(call.Stack)
+	trace, hasSpanId, _ := findTraceKeys(pass, funcBody(funcs[len(funcs)-1]), cfg, pairs)
+	if trace != nil {
+		return false
+	}
+
+	logger := heldLogger(pass, call)
+
+	// The logger is declared in the function declaring the context, for
+	// the function literals it encloses to use it too.
+	call.FuncNode = call.ContextFunc
+	kinds := []traceKind{traceIdKind}
+	if !hasSpanId {
+		kinds = append(kinds, spanIdKind)
+	}
+	if cfg.RequireTraceFlags {
+		kinds = append(kinds, traceFlagsKind)
+	}
+	fields, edits, err := traceFields(pass, call, cfg, findPosOfFuncBody(call.FuncNode), kinds...)
+	if err == nil {
+		var loggerEdits []analysis.TextEdit
+		loggerEdits, err = structLoggerEdits(pass, call, cfg, logger, fields)
+		edits = append(edits, loggerEdits...)
+	}
+	expr := types.ExprString(logger)
+	reportMissingKey(pass, call,
+		fmt.Sprintf("missing traceId in logging keys of %s, held by a struct", expr),
+		"Derive a logger with traceId and spanId from "+expr, edits, err)
+	return true
+}
+
+// structLoggerEdits returns the edits declaring a logger deriving from the
+// struct's logger with the fields, and replacing the struct's logger with it
+// in the function declaring the context.
+func structLoggerEdits(pass *analysis.Pass, call CallContext, cfg Config, logger *ast.SelectorExpr, fields []traceField) ([]analysis.TextEdit, error) {
+	derive := deriveMethod(pass, cfg, pass.TypesInfo.TypeOf(logger))
+	if derive == nil {
+		return nil, fmt.Errorf("no method of %s adds values to the logger", types.TypeString(pass.TypesInfo.TypeOf(logger), types.RelativeTo(pass.Pkg)))
+	}
+	c := cfg.CheckerFor(derive)
+	if c == nil {
+		return nil, fmt.Errorf("%s is not checked", derive.FullName())
+	}
+
+	fun := call.FuncNode
+	name := freeName(pass, fun, structLoggerNames)
+	if name == "" {
+		return nil, fmt.Errorf("the names %s are already in use", strings.Join(structLoggerNames, ", "))
+	}
+
+	uses, assigned := fieldUses(pass, funcBody(fun), logger)
+	if assigned {
+		return nil, fmt.Errorf("%s is assigned in the function", types.ExprString(logger))
+	}
+
+	deriveCall := CallContext{Func: derive, Signature: derive.Type().(*types.Signature), File: call.File}
+	args := make([]string, len(fields))
+	for i, f := range fields {
+		args[i] = formatField(c, deriveCall, f)
+	}
+	pos := findPosOfFuncBody(fun)
+	declaration := name + " := " + types.ExprString(logger) + "." + derive.Name() + "(" + strings.Join(args, ", ") + ")"
+	edits := []analysis.TextEdit{insertStmt(pass, pos, declaration)}
+	for _, use := range uses {
+		edits = append(edits, analysis.TextEdit{Pos: use.Pos(), End: use.End(), NewText: []byte(name)})
+	}
+	return edits, nil
+}
+
+// deriveMethod returns the method of the logger type adding values to the
+// logger it returns, as selected by the withvalues trace rules, such as
+// logr's WithValues or zap's With, or nil.
+func deriveMethod(pass *analysis.Pass, cfg Config, typ types.Type) *types.Func {
+	if typ == nil {
+		return nil
+	}
+	mset := types.NewMethodSet(typ)
+	for i := range cfg.TraceRules {
+		rs := &cfg.TraceRules[i]
+		if rs.Name != TracePolicyWithValues {
+			continue
+		}
+		for j := 0; j < mset.Len(); j++ {
+			fn, ok := mset.At(j).Obj().(*types.Func)
+			if !ok || !matchRuleset(rs, fn) {
+				continue
+			}
+			sig := fn.Type().(*types.Signature)
+			if sig.Variadic() && sig.Results().Len() == 1 && types.Identical(sig.Results().At(0).Type(), typ) {
+				return fn
+			}
+		}
+	}
+	return nil
+}
+
+// freeName returns the first of the names which can be declared at the
+// start of the function without conflicting with, or shadowing, another
+// declaration, or "".
+func freeName(pass *analysis.Pass, fun ast.Node, names []string) string {
+	for _, name := range names {
+		if !isNameInUse(pass, fun, name) && !isNameInBody(funcBody(fun), name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// isNameInBody reports whether an identifier of the body, other than a
+// selected field or method, is named name, which a declaration at the
+// start of the body would conflict with.
+func isNameInBody(body ast.Node, name string) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			found = found || isNameInBody(n.X, name)
+			return false
+		case *ast.Ident:
+			found = found || n.Name == name
+		}
+		return !found
+	})
+	return found
+}
+
+// fieldUses returns the selections of the same field of the same variable
+// as logger in the body, and whether one of them is assigned.
+func fieldUses(pass *analysis.Pass, body *ast.BlockStmt, logger *ast.SelectorExpr) ([]*ast.SelectorExpr, bool) {
+	field := pass.TypesInfo.Selections[logger].Obj()
+	root := fieldRoot(pass, logger)
+	path := types.ExprString(logger)
+
+	var uses []*ast.SelectorExpr
+	assigned := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if sel, ok := astutil.Unparen(lhs).(*ast.SelectorExpr); ok && isSameField(pass, sel, field, root, path) {
+					assigned = true
+				}
+			}
+		case *ast.UnaryExpr:
+			if sel, ok := astutil.Unparen(n.X).(*ast.SelectorExpr); ok && n.Op == token.AND && isSameField(pass, sel, field, root, path) {
+				assigned = true // the address of the field may be assigned through
+			}
+		case *ast.SelectorExpr:
+			if isSameField(pass, n, field, root, path) {
+				uses = append(uses, n)
+				return false
+			}
+		}
+		return true
+	})
+	return uses, assigned
+}
+
+func isSameField(pass *analysis.Pass, sel *ast.SelectorExpr, field types.Object, root *types.Var, path string) bool {
+	s := pass.TypesInfo.Selections[sel]
+	return s != nil && s.Obj() == field && fieldRoot(pass, sel) == root && types.ExprString(sel) == path
+}
@@ -0,0 +1,650 @@
+package checkers
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+
+	"github.com/george-maroun/tracecheck/internal/keymatch"
+)
+
+// traceField is a key/value pair inserted into a logging call by a suggested fix.
+// The value is an expression of the span declared, or reused, by
+// traceFields, or of the context.
+type traceField struct {
+	key   string
+	value string
+}
+
+func (f traceField) String() string {
+	return strconv.Quote(f.key) + ", " + f.value
+}
+
+// entry returns the field as the entry of a map literal.
+func (f traceField) entry() string {
+	return strconv.Quote(f.key) + ": " + f.value
+}
+
+// traceKind is the kind of a trace field.
+type traceKind int
+
+const (
+	traceIdKind traceKind = iota
+	spanIdKind
+	traceFlagsKind
+)
+
+func (k traceKind) String() string {
+	return [...]string{"traceId", "spanId", "traceFlags"}[k]
+}
+
+// field returns the key and the value, with its placeholders, of the trace
+// field of the kind.
+func (t FixTemplate) field(cfg Config, kind traceKind) (key, value string) {
+	switch kind {
+	case traceIdKind:
+		return templateKey(t.TraceKey, cfg.TraceKeys, "traceId"), t.TraceID
+	case spanIdKind:
+		return templateKey(t.SpanKey, cfg.SpanKeys, "spanId"), t.SpanID
+	default:
+		return templateKey(t.TraceFlagsKey, cfg.TraceFlagKeys, "traceFlags"), t.TraceFlags
+	}
+}
+
+// templateKey returns the key of a fix template, or else the canonical key
+// of keys.
+func templateKey(key string, keys keymatch.List, def string) string {
+	if key != "" {
+		return key
+	}
+	return canonicalKey(keys, def)
+}
+
+// canonicalKey returns the key inserted by suggested fixes for keys,
+// falling back to def when keys only has pattern matchers.
+func canonicalKey(keys keymatch.List, def string) string {
+	if key, ok := keys.Canonical(); ok {
+		return key
+	}
+	return def
+}
+
+// keyValue is a key/value pair of a logging call, either given as two
+// arguments or as a single typed field, like slog.String(key, value).
+type keyValue struct {
+	key, value ast.Expr // value is nil for a key without a value
+	field      ast.Expr // nil unless the pair is a typed field
+}
+
+func (kv keyValue) Pos() token.Pos {
+	if kv.field != nil {
+		return kv.field.Pos()
+	}
+	return kv.key.Pos()
+}
+
+func (kv keyValue) End() token.Pos {
+	switch {
+	case kv.field != nil:
+		return kv.field.End()
+	case kv.value != nil:
+		return kv.value.End()
+	}
+	return kv.key.End()
+}
+
+// keyValuePairs returns the key/value pairs of the logging call, including
+// the typed fields understood by a FieldChecker.
+func keyValuePairs(pass *analysis.Pass, c Checker, call CallContext, keyValuesArgs []ast.Expr, startIndex int) []keyValue {
+	var pairs []keyValue
+	for i := 0; i < len(keyValuesArgs); i += 2 {
+		kv := keyValue{key: keyValuesArgs[i]}
+		if i+1 < len(keyValuesArgs) {
+			kv.value = keyValuesArgs[i+1]
+		}
+		pairs = append(pairs, kv)
+	}
+
+	if fc, ok := c.(FieldChecker); ok {
+		for _, arg := range call.Expr.Args[startIndex:] {
+			if key, value, ok := fc.FieldKeyValue(pass, arg); ok {
+				pairs = append(pairs, keyValue{key: key, value: value, field: arg})
+			}
+		}
+	}
+	return pairs
+}
+
+// formatField returns the source of a trace field inserted into the call.
+func formatField(c Checker, call CallContext, f traceField) string {
+	if fc, ok := c.(FieldChecker); ok {
+		return fc.FormatField(call, strconv.Quote(f.key), f.value)
+	}
+	return f.String()
+}
+
+// fieldImporter is implemented by field checkers whose fields may refer to
+// a package the file does not import yet.
+type fieldImporter interface {
+	// FieldImport returns the path of the package to import for the
+	// fields formatted for the call, if any.
+	FieldImport(call CallContext) (string, bool)
+}
+
+// fieldImportEdits returns the edits importing the package the trace fields
+// formatted by c for the call refer to, if needed.
+func fieldImportEdits(pass *analysis.Pass, c Checker, call CallContext) []analysis.TextEdit {
+	fi, ok := c.(fieldImporter)
+	if !ok {
+		return nil
+	}
+	lib, ok := fi.FieldImport(call)
+	if !ok {
+		return nil
+	}
+	return addImportEdits(pass.Fset, call.File, "", lib)
+}
+
+// checkTraceKeys reports logging calls that miss the trace keys of the
+// OpenTelemetry logs data model: traceId, spanId and, if required, the trace flags.
+// https://opentelemetry.io/docs/specs/otel/logs/data-model/#trace-context-fields
+//
+// A missing traceId is reported once, with a fix inserting every missing key.
+// Otherwise the span and the trace flags are reported separately, and each fix
+// only inserts its own key next to the existing traceId.
+func checkTraceKeys(pass *analysis.Pass, c Checker, call CallContext, cfg Config, pairs []keyValue, ins fieldInserter) {
+	// Keys may be assigned anywhere in the outermost function.
+	funcs := enclosingFuncs(call.Stack)
+	body := funcBody(funcs[len(funcs)-1])
+
+	trace, hasSpanId, hasTraceFlags := findTraceKeys(pass, body, cfg, pairs)
+	missingTraceFlags := cfg.RequireTraceFlags && !hasTraceFlags
+
+	if trace == nil {
+		// Add the missing trace fields to the logging call
+		kinds := []traceKind{traceIdKind}
+		if !hasSpanId {
+			kinds = append(kinds, spanIdKind)
+		}
+		if missingTraceFlags {
+			kinds = append(kinds, traceFlagsKind)
+		}
+		fields, edits, err := traceFields(pass, call, cfg, call.Expr.Pos(), kinds...)
+		if err == nil {
+			edits = append(edits, fieldImportEdits(pass, c, call)...)
+			edits = append(edits, ins.insertFields(fields)...)
+		}
+		reportMissingKey(pass, call, "missing traceId in logging keys", addFieldsMessage(kinds), edits, err)
+		return
+	}
+
+	if cfg.VerifyTraceValue && trace.value != nil {
+		checkTraceValue(pass, call, cfg, *trace)
+	}
+
+	if !hasSpanId {
+		var edits []analysis.TextEdit
+		var err error
+		if trace.value != nil {
+			var fields []traceField
+			fields, edits, err = traceFields(pass, call, cfg, call.Expr.Pos(), spanIdKind)
+			if err == nil {
+				edits = append(edits, fieldImportEdits(pass, c, call)...)
+				edits = append(edits, ins.insertAfter(*trace, fields[0]))
+			}
+		}
+		reportMissingKey(pass, call, "missing spanId in logging keys", addFieldsMessage([]traceKind{spanIdKind}), edits, err)
+	}
+
+	if missingTraceFlags {
+		fields, edits, err := traceFields(pass, call, cfg, call.Expr.Pos(), traceFlagsKind)
+		if err == nil {
+			edits = append(edits, fieldImportEdits(pass, c, call)...)
+			edits = append(edits, ins.insertBefore(*trace, fields[0]))
+		}
+		reportMissingKey(pass, call, "missing trace flags in logging keys", addFieldsMessage([]traceKind{traceFlagsKind}), edits, err)
+	}
+}
+
+// addFieldsMessage returns the message of a fix inserting fields of the
+// kinds, e.g. "Add traceId and spanId to logging keys".
+func addFieldsMessage(kinds []traceKind) string {
+	names := make([]string, len(kinds))
+	for i, kind := range kinds {
+		names[i] = kind.String()
+		if kind == traceFlagsKind {
+			names[i] = "trace flags"
+		}
+	}
+	list := names[len(names)-1]
+	if len(names) > 1 {
+		list = strings.Join(names[:len(names)-1], ", ") + " and " + list
+	}
+	return "Add " + list + " to logging keys"
+}
+
+// findTraceKeys returns the pair holding the traceId, and whether the span
+// and the trace flags keys are present, among the pairs of a logging call
+// of the function whose body is given. A key with several possible values
+// is taken for each of them.
+func findTraceKeys(pass *analysis.Pass, body *ast.BlockStmt, cfg Config, pairs []keyValue) (trace *keyValue, hasSpanId, hasTraceFlags bool) {
+	for i := range pairs {
+		// We use traceId not traceID based on spanId in Google stackdriver stuctured logging
+		// https://cloud.google.com/logging/docs/structured-logging
+		// In the opentelemetry docs it is "TraceId"
+		// https://opentelemetry.io/docs/specs/otel/trace/api/#retrieving-the-traceid-and-spanid
+		// It looks like in the wire format of the w3c spec it might be trace-id
+		// https://www.w3.org/TR/trace-context/#trace-id
+		// This is also how its defined in the OpenTelemetry spec for jsonLogs
+		// https://opentelemetry.io/docs/specs/otel/protocol/file-exporter/#examples
+		// https://opentelemetry.io/docs/specs/otel/logs/
+		for _, key := range resolveKey(pass, body, pairs[i].key) {
+			if trace == nil && cfg.TraceKeys.Match(key) {
+				trace = &pairs[i]
+			}
+			hasSpanId = hasSpanId || cfg.SpanKeys.Match(key)
+			hasTraceFlags = hasTraceFlags || cfg.TraceFlagKeys.Match(key)
+		}
+	}
+	return trace, hasSpanId, hasTraceFlags
+}
+
+// fieldInserter builds the edits inserting trace fields into a logging call.
+type fieldInserter interface {
+	// insertFields inserts fields where the fields of the call start.
+	insertFields(fields []traceField) []analysis.TextEdit
+	// insertAfter inserts f right after kv.
+	insertAfter(kv keyValue, f traceField) analysis.TextEdit
+	// insertBefore inserts f right before kv.
+	insertBefore(kv keyValue, f traceField) analysis.TextEdit
+}
+
+// argsInserter inserts fields into the arguments of a logging call, the
+// key/value pairs starting at startIndex.
+type argsInserter struct {
+	c          Checker
+	call       CallContext
+	startIndex int
+}
+
+// insertFields inserts the fields at the boundary of the key/value pairs,
+// leaving the text of the existing arguments, their comments and line
+// breaks untouched.
+func (ins argsInserter) insertFields(fields []traceField) []analysis.TextEdit {
+	formatted := make([]string, len(fields))
+	for i, f := range fields {
+		formatted[i] = formatField(ins.c, ins.call, f)
+	}
+	text := strings.Join(formatted, ", ")
+
+	args := ins.call.Expr.Args
+	switch {
+	case ins.startIndex < len(args):
+		return []analysis.TextEdit{insertText(args[ins.startIndex].Pos(), text+", ")}
+	case len(args) > 0:
+		return []analysis.TextEdit{insertText(args[len(args)-1].End(), ", "+text)}
+	}
+	return []analysis.TextEdit{insertText(ins.call.Expr.Lparen+1, text)}
+}
+
+func (ins argsInserter) insertAfter(kv keyValue, f traceField) analysis.TextEdit {
+	return insertText(kv.End(), ", "+formatField(ins.c, ins.call, f))
+}
+
+func (ins argsInserter) insertBefore(kv keyValue, f traceField) analysis.TextEdit {
+	return insertText(kv.Pos(), formatField(ins.c, ins.call, f)+", ")
+}
+
+// wrapInserter inserts missing fields into the arguments of a function
+// wrapping the logger of the call, e.g. log.With(logger, fields...).Log(...),
+// and other fields next to the existing ones, like argsInserter.
+type wrapInserter struct {
+	argsInserter
+	wrap   string
+	logger ast.Expr
+}
+
+func (ins wrapInserter) insertFields(fields []traceField) []analysis.TextEdit {
+	args := make([]string, len(fields))
+	for i, f := range fields {
+		args[i] = formatField(ins.c, ins.call, f)
+	}
+	return []analysis.TextEdit{
+		insertText(ins.logger.Pos(), ins.wrap+"("),
+		insertText(ins.logger.End(), ", "+strings.Join(args, ", ")+")"),
+	}
+}
+
+func insertText(pos token.Pos, text string) analysis.TextEdit {
+	return analysis.TextEdit{Pos: pos, End: pos, NewText: []byte(text)}
+}
+
+// ReportedEdits records the edits of the fixes reported in a pass. The fixes
+// of a file are applied together, by the -fix driver as by analysistest, so
+// an edit made by an earlier fix, such as the span declaration shared by the
+// calls of a function, is left out of the later fixes, which rely on it.
+type ReportedEdits map[reportedEdit]bool
+
+type reportedEdit struct {
+	pos, end token.Pos
+	text     string
+}
+
+// add records the edits, and returns those which no earlier fix makes.
+func (r ReportedEdits) add(edits []analysis.TextEdit) []analysis.TextEdit {
+	if r == nil {
+		return edits
+	}
+	var added []analysis.TextEdit
+	for _, edit := range edits {
+		key := reportedEdit{edit.Pos, edit.End, string(edit.NewText)}
+		if !r[key] {
+			r[key] = true
+			added = append(added, edit)
+		}
+	}
+	return added
+}
+
+// insertStmt returns the edit inserting the statement, formatted by
+// go/format, on its own line before the statement at pos, indented like it
+// with tabs as in a file formatted by gofmt.
+func insertStmt(pass *analysis.Pass, pos token.Pos, stmt string) analysis.TextEdit {
+	if src, err := format.Source([]byte(stmt)); err == nil {
+		stmt = strings.TrimSpace(string(src))
+	}
+	indent := strings.Repeat("\t", pass.Fset.Position(pos).Column-1)
+	return insertText(pos, stmt+"\n"+indent)
+}
+
+// reportMissingKey reports a missing trace key at the logging call. The
+// fix is omitted when there are no edits left once those of earlier fixes
+// are, or when fixErr explains why the fix cannot be applied.
+func reportMissingKey(pass *analysis.Pass, call CallContext, message, fixMessage string, edits []analysis.TextEdit, fixErr error) {
+	if fixErr != nil {
+		message = fmt.Sprintf("%s, cannot suggest a fix: %v", message, fixErr)
+		edits = nil
+	}
+	edits = call.Reported.add(edits)
+	d := analysis.Diagnostic{
+		Category: DiagnosticCategory,
+		Message:  message,
+		// Here's where we set the position at which to report this.
+		// We use the position of call argument
+		Pos: call.Expr.Pos(),
+		// N.B we don't set end because it should just apply to the entire line pointed at by pos.
+	}
+	if len(edits) > 0 {
+		d.SuggestedFixes = []analysis.SuggestedFix{
+			{
+				Message: fixMessage,
+				// Edit the code to make the fix.
+				TextEdits: edits,
+			},
+		}
+	}
+	pass.Report(d)
+}
+
+// spanNames are the names tried in order for the span declared by
+// traceFields.
+var spanNames = []string{"span", "traceSpan", "otelSpan"}
+
+// traceFields returns the trace fields of the kinds inserted into the call
+// at pos, with the edits declaring the span and importing the packages they
+// use, as given by cfg.FixTemplate.
+func traceFields(pass *analysis.Pass, call CallContext, cfg Config, pos token.Pos, kinds ...traceKind) ([]traceField, []analysis.TextEdit, error) {
+	f := &traceFix{pass: pass, call: call, tmpl: cfg.FixTemplate, qualifiers: make(map[string]string)}
+	if f.tmpl.Declaration != "" {
+		if err := f.declareSpan(pos); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		ctx, edits, err := contextExpr(pass, call, pos)
+		if err != nil {
+			return nil, nil, err
+		}
+		f.vars = []string{"{ctx}", ctx}
+		f.edits = edits
+	}
+
+	fields := make([]traceField, len(kinds))
+	for i, kind := range kinds {
+		key, value := f.tmpl.field(cfg, kind)
+		if value == "" {
+			return nil, nil, fmt.Errorf("the %s fix template has no %s value", f.tmpl.Name, kind)
+		}
+		value, err := f.expand(value, pos)
+		if err != nil {
+			return nil, nil, err
+		}
+		fields[i] = traceField{key, value}
+	}
+	return fields, f.edits, nil
+}
+
+// traceFix expands the code of a fix template for a call.
+type traceFix struct {
+	pass       *analysis.Pass
+	call       CallContext
+	tmpl       FixTemplate
+	vars       []string          // placeholders of {ctx} and {span}, followed by their value
+	qualifiers map[string]string // qualifiers of the imports, by placeholder
+	edits      []analysis.TextEdit
+}
+
+// expand returns the code with its placeholders replaced, importing the
+// packages it uses where the code at pos refers to them, unless an earlier
+// expansion did.
+func (f *traceFix) expand(code string, pos token.Pos) (string, error) {
+	replacements := append([]string{}, f.vars...)
+	for _, imp := range f.tmpl.Imports {
+		placeholder := imp.placeholder()
+		if !strings.Contains(code, placeholder) {
+			continue
+		}
+		qualifier, ok := f.qualifiers[placeholder]
+		if !ok {
+			var edits []analysis.TextEdit
+			var err error
+			qualifier, edits, err = importQualifier(f.pass, f.call.File, pos, imp)
+			if err != nil {
+				return "", err
+			}
+			f.qualifiers[placeholder] = qualifier
+			f.edits = append(f.edits, edits...)
+		}
+		replacements = append(replacements, placeholder, qualifier)
+	}
+	return strings.NewReplacer(replacements...).Replace(code), nil
+}
+
+// declareSpan sets {span} to the span of the context at pos. A variable of
+// the span type of the template in scope at pos, such as the span of
+// ctx, span := tracer.Start(ctx, "op"), is reused unless the context is
+// assigned after it. Otherwise the span is declared where
+// spanDeclarationPos places it.
+func (f *traceFix) declareSpan(pos token.Pos) error {
+	pass, call := f.pass, f.call
+	spanInsertPos, lastWrite := spanDeclarationPos(pass, call, pos)
+	if span := spanInScope(pass, call, f.tmpl, pos, lastWrite); span != nil {
+		f.vars = []string{"{span}", span.Name()}
+		return nil
+	}
+
+	ctx, edits, err := contextExpr(pass, call, spanInsertPos)
+	if err != nil {
+		return err
+	}
+	// The spans declared after different assignments of the context are
+	// numbered after them, e.g. span2 after the first, for the fixes of the
+	// calls before and after an assignment not to declare the same name.
+	names := spanNames
+	if lastWrite != nil {
+		suffix := strconv.Itoa(contextWrites(pass, call, lastWrite.End()) + 1)
+		names = make([]string, len(spanNames))
+		for i, name := range spanNames {
+			names[i] = name + suffix
+		}
+	}
+	name := freeName(pass, call.FuncNode, names)
+	if name == "" {
+		return fmt.Errorf("the names %s are already in use", strings.Join(names, ", "))
+	}
+
+	// Add span declaration after the context is last assigned
+	f.vars = []string{"{ctx}", ctx, "{span}", name}
+	f.edits = append(f.edits, edits...)
+	declaration, err := f.expand(f.tmpl.Declaration, spanInsertPos)
+	if err != nil {
+		return err
+	}
+	f.edits = append(f.edits, insertStmt(pass, spanInsertPos, declaration))
+	return nil
+}
+
+// spanDeclarationPos returns where the span used at pos is declared: right
+// before the statement following the last one assigning the context
+// parameter before pos, in the innermost block enclosing pos which has one,
+// e.g. ctx, _ = tracer.Start(ctx, "op"), for the span to be the one of the
+// context at pos, or else at the start of the innermost function enclosing
+// the call. The last statement assigning the context is returned too.
+func spanDeclarationPos(pass *analysis.Pass, call CallContext, pos token.Pos) (token.Pos, ast.Stmt) {
+	body := funcBody(call.FuncNode)
+	path, _ := astutil.PathEnclosingInterval(call.File, pos, pos)
+	for i := 1; i < len(path); i++ {
+		var list []ast.Stmt
+		switch n := path[i].(type) {
+		case *ast.BlockStmt:
+			list = n.List
+		case *ast.CaseClause:
+			list = n.Body
+		case *ast.CommClause:
+			list = n.Body
+		}
+
+		for j := len(list) - 1; j >= 0; j-- {
+			if list[j] == path[i-1] {
+				for k := j - 1; k >= 0; k-- {
+					if assignsContext(pass, call, list[k]) {
+						return list[k+1].Pos(), list[k]
+					}
+				}
+				break
+			}
+		}
+		if path[i] == body {
+			break
+		}
+	}
+	return findPosOfFuncBody(call.FuncNode), nil
+}
+
+// assignsContext reports whether the statement assigns the context
+// parameter, or declares a variable of the same type and name, outside of
+// function literals.
+func assignsContext(pass *analysis.Pass, call CallContext, stmt ast.Node) bool {
+	param := call.ContextParam
+	if param.Name() == "" || param.Name() == "_" {
+		return false
+	}
+
+	assigns := false
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if ident, ok := astutil.Unparen(lhs).(*ast.Ident); ok && isContextVar(pass, param, ident) {
+					assigns = true
+				}
+			}
+		case *ast.ValueSpec:
+			for _, ident := range n.Names {
+				if isContextVar(pass, param, ident) {
+					assigns = true
+				}
+			}
+		}
+		return !assigns
+	})
+	return assigns
+}
+
+// contextWrites returns the number of assignments of the context in the
+// innermost function enclosing the call, up to end.
+func contextWrites(pass *analysis.Pass, call CallContext, end token.Pos) int {
+	n := 0
+	ast.Inspect(funcBody(call.FuncNode), func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.AssignStmt, *ast.ValueSpec:
+			if node.End() <= end && assignsContext(pass, call, node) {
+				n++
+			}
+		}
+		return true
+	})
+	return n
+}
+
+// isContextVar reports whether ident is the context parameter, or declares
+// a variable of the same type and name.
+func isContextVar(pass *analysis.Pass, param *types.Var, ident *ast.Ident) bool {
+	if pass.TypesInfo.Uses[ident] == param {
+		return true
+	}
+	obj := pass.TypesInfo.Defs[ident]
+	return obj != nil && obj.Name() == param.Name() && types.Identical(obj.Type(), param.Type())
+}
+
+// spanInScope returns the variable of the span type of the template visible
+// at pos, declared in the function declaring the context, or a function it
+// encloses, and at or after the statement lastWrite last assigning the
+// context, if any. The latest declared one of the innermost scope having
+// one is returned, or nil.
+func spanInScope(pass *analysis.Pass, call CallContext, tmpl FixTemplate, pos token.Pos, lastWrite ast.Stmt) *types.Var {
+	if tmpl.SpanType == "" {
+		return nil
+	}
+	fun := call.ContextFunc
+	if fun == nil {
+		funcs := enclosingFuncs(call.Stack)
+		fun = funcs[len(funcs)-1]
+	}
+	scope := pass.TypesInfo.Scopes[funcType(fun)]
+	if scope == nil {
+		return nil
+	}
+	from := fun.Pos()
+	if lastWrite != nil {
+		from = lastWrite.Pos()
+	}
+
+	for s := scope.Innermost(pos); s != nil && s != pass.Pkg.Scope(); s = s.Parent() {
+		var span *types.Var
+		for _, name := range s.Names() {
+			_, obj := s.LookupParent(name, pos)
+			v, ok := obj.(*types.Var)
+			if !ok || v.Parent() != s || v.Pos() < from || v.Pos() >= fun.End() {
+				continue
+			}
+			if tmpl.matchSpanType(v.Type()) && (span == nil || v.Pos() > span.Pos()) {
+				span = v
+			}
+		}
+		if span != nil {
+			return span
+		}
+	}
+	return nil
+}
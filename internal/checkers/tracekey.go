@@ -0,0 +1,243 @@
+package checkers
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// maxResolveDepth bounds how many assignments resolveKey follows, so
+// that cyclic assignments like `a = b; b = a` cannot recurse forever.
+const maxResolveDepth = 8
+
+// resolveKey returns the possible string values of a logging key.
+//
+// Constant expressions, including constants declared in other packages, are
+// folded by the type checker. Variables and struct fields are resolved by
+// looking up the assignments to them before the key in body (or, for
+// package-level variables, their initializer), so the actual key string can
+// be compared rather than the name of the identifier holding it.
+//
+// A variable or field assigned more than once before the key, as in
+//
+//	k := "eventType"
+//	if x {
+//		k = "msg"
+//	}
+//
+// holds the value of the path taken, which the source order does not tell:
+// the values of every assignment are returned.
+func resolveKey(pass *analysis.Pass, body *ast.BlockStmt, key ast.Expr) []string {
+	r := &keyResolver{pass: pass, body: body}
+	return r.resolve(key, 0)
+}
+
+type keyResolver struct {
+	pass *analysis.Pass
+	body *ast.BlockStmt
+}
+
+func (r *keyResolver) resolve(expr ast.Expr, depth int) []string {
+	if value, ok := extractValueFromStringArg(r.pass, expr); ok {
+		return []string{value}
+	}
+	if depth >= maxResolveDepth {
+		return nil
+	}
+
+	var values []string
+	for _, rhs := range r.assignments(expr) {
+		values = append(values, r.resolve(rhs, depth+1)...)
+	}
+	return values
+}
+
+// assignments returns the expressions assigned to the variable or the
+// struct field expr before it.
+func (r *keyResolver) assignments(expr ast.Expr) []ast.Expr {
+	switch expr := astutil.Unparen(expr).(type) {
+	case *ast.Ident:
+		if v, ok := r.pass.TypesInfo.Uses[expr].(*types.Var); ok {
+			return r.varAssignments(v, expr.Pos())
+		}
+	case *ast.SelectorExpr:
+		sel := r.pass.TypesInfo.Selections[expr]
+		if sel == nil {
+			// Qualified identifier, e.g. otherpkg.TraceKey.
+			if v, ok := r.pass.TypesInfo.Uses[expr.Sel].(*types.Var); ok {
+				return r.varAssignments(v, expr.Pos())
+			}
+			return nil
+		}
+		if sel.Kind() != types.FieldVal {
+			return nil
+		}
+		base, ok := astutil.Unparen(expr.X).(*ast.Ident)
+		if !ok {
+			return nil
+		}
+		baseVar, ok := r.pass.TypesInfo.Uses[base].(*types.Var)
+		if !ok {
+			return nil
+		}
+		return r.fieldAssignments(baseVar, sel.Obj().(*types.Var), expr.Pos())
+	}
+	return nil
+}
+
+// varAssignment returns the expression assigned to v before pos, or nil
+// if there are several.
+func (r *keyResolver) varAssignment(v *types.Var, pos token.Pos) ast.Expr {
+	if assigned := r.varAssignments(v, pos); len(assigned) == 1 {
+		return assigned[0]
+	}
+	return nil
+}
+
+// varAssignments returns the expressions assigned to v before pos.
+func (r *keyResolver) varAssignments(v *types.Var, pos token.Pos) []ast.Expr {
+	assignedTo := func(lhs, rhs ast.Expr) ast.Expr {
+		if ident, ok := lhs.(*ast.Ident); ok && r.objectOf(ident) == v {
+			return rhs
+		}
+		return nil
+	}
+
+	if r.body != nil {
+		if assigned := r.findAssignments(r.body, pos, assignedTo); len(assigned) > 0 {
+			return assigned
+		}
+	}
+
+	// Fall back to the initializer of a package-level variable.
+	if v.Pkg() != r.pass.Pkg || v.Parent() != v.Pkg().Scope() {
+		return nil
+	}
+	for _, file := range r.pass.Files {
+		if init := r.findAssignments(file, token.NoPos, assignedTo); len(init) > 0 {
+			return init
+		}
+	}
+	return nil
+}
+
+// fieldAssignments returns the expressions assigned to the field of the
+// struct held in base before pos. Both `base.field = x` assignments and
+// composite literals assigned to base are taken into account.
+func (r *keyResolver) fieldAssignments(base, field *types.Var, pos token.Pos) []ast.Expr {
+	if r.body == nil {
+		return nil
+	}
+
+	return r.findAssignments(r.body, pos, func(lhs, rhs ast.Expr) ast.Expr {
+		switch lhs := lhs.(type) {
+		case *ast.Ident:
+			if r.objectOf(lhs) == base {
+				return r.compositeLitField(rhs, field)
+			}
+		case *ast.SelectorExpr:
+			ident, ok := astutil.Unparen(lhs.X).(*ast.Ident)
+			if ok && r.objectOf(ident) == base && r.pass.TypesInfo.Uses[lhs.Sel] == field {
+				return rhs
+			}
+		}
+		return nil
+	})
+}
+
+// compositeLitField returns the value of field in the struct literal expr,
+// which may be keyed or positional, and may have its address taken.
+func (r *keyResolver) compositeLitField(expr ast.Expr, field *types.Var) ast.Expr {
+	expr = astutil.Unparen(expr)
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = astutil.Unparen(unary.X)
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+
+	st, ok := r.pass.TypesInfo.TypeOf(lit).Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+	for i, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok && r.pass.TypesInfo.Uses[key] == field {
+				return kv.Value
+			}
+			continue
+		}
+		if i < st.NumFields() && st.Field(i) == field {
+			return elt
+		}
+	}
+	return nil
+}
+
+// findAssignments returns the values of the assignments and declarations
+// within root for which value returns non-nil. If pos is valid, only
+// assignments before pos are considered.
+func (r *keyResolver) findAssignments(root ast.Node, pos token.Pos, value func(lhs, rhs ast.Expr) ast.Expr) []ast.Expr {
+	var found []ast.Expr
+	ast.Inspect(root, func(n ast.Node) bool {
+		if n == nil || (pos.IsValid() && n.Pos() >= pos) {
+			return false
+		}
+		lhs, rhs := assignmentOperands(n)
+		for i := range lhs {
+			if rhs[i] == nil {
+				continue // declared without a value
+			}
+			if v := value(lhs[i], rhs[i]); v != nil {
+				found = append(found, v)
+			}
+		}
+		return true
+	})
+	return found
+}
+
+func (r *keyResolver) objectOf(ident *ast.Ident) types.Object {
+	if obj := r.pass.TypesInfo.Defs[ident]; obj != nil {
+		return obj
+	}
+	return r.pass.TypesInfo.Uses[ident]
+}
+
+// assignmentOperands returns the pairwise operands of a plain assignment
+// or a variable declaration. For a tuple assignment from a single call,
+// such as `logger, err := logr.FromContext(ctx)`, every operand is paired
+// with the call.
+func assignmentOperands(n ast.Node) (lhs, rhs []ast.Expr) {
+	switch n := n.(type) {
+	case *ast.AssignStmt:
+		if n.Tok == token.ASSIGN || n.Tok == token.DEFINE {
+			return n.Lhs, pairedValues(len(n.Lhs), n.Rhs)
+		}
+	case *ast.ValueSpec:
+		lhs = make([]ast.Expr, len(n.Names))
+		for i, name := range n.Names {
+			lhs[i] = name
+		}
+		return lhs, pairedValues(len(lhs), n.Values)
+	}
+	return nil, nil
+}
+
+func pairedValues(n int, values []ast.Expr) []ast.Expr {
+	switch len(values) {
+	case n:
+		return values
+	case 1:
+		paired := make([]ast.Expr, n)
+		for i := range paired {
+			paired[i] = values[0]
+		}
+		return paired
+	}
+	return make([]ast.Expr, n)
+}
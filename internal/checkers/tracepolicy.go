@@ -0,0 +1,121 @@
+package checkers
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/george-maroun/tracecheck/internal/rules"
+)
+
+// Trace policies select the logging calls which require trace fields. The
+// functions of each policy are the rulesets named after it.
+const (
+	// TracePolicyConstructor selects calls on a logger returned by a
+	// constructor, e.g. zapr.NewLogger(l).WithValues(...).
+	TracePolicyConstructor = "constructor"
+	// TracePolicyFromContext selects calls on a logger taken from the
+	// context, e.g. logr.FromContextOrDiscard(ctx).WithValues(...).
+	TracePolicyFromContext = "fromcontext"
+	// TracePolicyWithValues selects every call adding values to a logger.
+	TracePolicyWithValues = "withvalues"
+	// TracePolicyLogCall selects every call writing a log entry.
+	TracePolicyLogCall = "logcall"
+)
+
+// TracePolicies lists the valid trace policy names.
+var TracePolicies = []string{TracePolicyConstructor, TracePolicyFromContext, TracePolicyWithValues, TracePolicyLogCall}
+
+// requiresTrace reports whether the logging call is selected by one of the
+// enabled trace policies.
+func requiresTrace(pass *analysis.Pass, call CallContext, cfg Config) bool {
+	var origin *types.Func
+	originResolved := false
+	for i := range cfg.TraceRules {
+		rs := &cfg.TraceRules[i]
+		if !cfg.TracePolicy.Has(rs.Name) {
+			continue
+		}
+
+		switch rs.Name {
+		case TracePolicyConstructor, TracePolicyFromContext:
+			if !originResolved {
+				origin, originResolved = loggerOrigin(pass, call), true
+			}
+			if matchRuleset(rs, origin) {
+				return true
+			}
+		default:
+			if matchRuleset(rs, call.Func) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// loggerExpr returns the logger of the logging call: the receiver of a
+// method, or the first argument of a function returning the logger it
+// wraps, such as log.With(logger Logger, keyvals ...interface{}) Logger.
+func loggerExpr(pass *analysis.Pass, call CallContext) ast.Expr {
+	if sel, ok := astutil.Unparen(call.Expr.Fun).(*ast.SelectorExpr); ok && pass.TypesInfo.Selections[sel] != nil {
+		return sel.X
+	}
+
+	sig, ok := pass.TypesInfo.TypeOf(call.Expr.Fun).(*types.Signature)
+	if !ok || len(call.Expr.Args) == 0 {
+		return nil
+	}
+	params, results := sig.Params(), sig.Results()
+	if params.Len() == 0 || results.Len() != 1 {
+		return nil
+	}
+	if !types.Identical(params.At(0).Type(), results.At(0).Type()) {
+		return nil
+	}
+	return call.Expr.Args[0]
+}
+
+func matchRuleset(rs *rules.Ruleset, fn *types.Func) bool {
+	return fn != nil && fn.Pkg() != nil && isPkgPath(fn.Pkg().Path(), rs.PackageImport) && rs.Match(fn)
+}
+
+// loggerOrigin returns the function which returned the receiver of the
+// logging call, or the logger wrapped by a function such as kitlog's
+// log.With(logger, ...), following the assignments to local variables, e.g.
+// logr.FromContext for:
+//
+//	logger, _ := logr.FromContext(ctx)
+//	logger.WithValues(...)
+func loggerOrigin(pass *analysis.Pass, call CallContext) *types.Func {
+	expr := loggerExpr(pass, call)
+	if expr == nil {
+		return nil
+	}
+
+	funcs := enclosingFuncs(call.Stack)
+	if len(funcs) == 0 {
+		return nil
+	}
+	r := &keyResolver{pass: pass, body: funcBody(funcs[len(funcs)-1])}
+
+	for depth := 0; depth < maxResolveDepth && expr != nil; depth++ {
+		switch x := astutil.Unparen(expr).(type) {
+		case *ast.CallExpr:
+			fn, _ := typeutil.Callee(pass.TypesInfo, x).(*types.Func)
+			return fn
+		case *ast.Ident:
+			v, ok := pass.TypesInfo.Uses[x].(*types.Var)
+			if !ok {
+				return nil
+			}
+			expr = r.varAssignment(v, x.Pos())
+		default:
+			return nil
+		}
+	}
+	return nil
+}
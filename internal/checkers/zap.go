@@ -3,8 +3,16 @@ package checkers
 import (
 	"go/ast"
 	"go/types"
+	"path"
 
 	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const (
+	zapPkg     = "go.uber.org/zap"
+	zapcorePkg = "go.uber.org/zap/zapcore"
 )
 
 type Zap struct {
@@ -12,25 +20,12 @@ type Zap struct {
 }
 
 func (z Zap) FilterKeyAndValues(pass *analysis.Pass, keyAndValues []ast.Expr) []ast.Expr {
-	// Check the argument count
 	filtered := make([]ast.Expr, 0, len(keyAndValues))
 	for _, arg := range keyAndValues {
-		// Skip any zapcore.Field we found
-		switch arg := arg.(type) {
-		case *ast.CallExpr, *ast.Ident:
-			typ := pass.TypesInfo.TypeOf(arg)
-			switch typ := typ.(type) {
-			case *types.Named:
-				obj := typ.Obj()
-				// This is a strongly-typed field. Consume it and move on.
-				// Actually it's go.uber.org/zap/zapcore.Field, however for simplicity
-				// we don't check the import path
-				if obj != nil && obj.Name() == "Field" {
-					continue
-				}
-			default:
-				// pass
-			}
+		// A strongly-typed field is used as is and consumes a single
+		// argument.
+		if isZapField(pass.TypesInfo.TypeOf(arg)) {
+			continue
 		}
 
 		filtered = append(filtered, arg)
@@ -39,4 +34,64 @@ func (z Zap) FilterKeyAndValues(pass *analysis.Pass, keyAndValues []ast.Expr) []
 	return filtered
 }
 
-var _ Checker = (*Zap)(nil)
+// FieldKeyValue returns the key and value of a field built by one of the
+// zap field constructors taking the key first, such as zap.String,
+// zap.Stringer, zap.Any or zap.Object.
+func (z Zap) FieldKeyValue(pass *analysis.Pass, arg ast.Expr) (key, value ast.Expr, ok bool) {
+	call, ok := astutil.Unparen(arg).(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 {
+		return nil, nil, false
+	}
+	fn, _ := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if fn == nil || fn.Pkg() == nil || !isPkgPath(fn.Pkg().Path(), zapPkg) {
+		return nil, nil, false
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Recv() != nil || sig.Params().Len() != 2 || sig.Params().At(0).Name() != "key" || sig.Results().Len() != 1 {
+		return nil, nil, false
+	}
+	if !isZapField(sig.Results().At(0).Type()) {
+		return nil, nil, false
+	}
+	return call.Args[0], call.Args[1], true
+}
+
+// FormatField returns a zap.String field, of the package imported by
+// FieldImport if the file does not import zap, or a plain key/value pair
+// for the SugaredLogger.
+func (z Zap) FormatField(call CallContext, key, value string) string {
+	if isSugared(call) {
+		return key + ", " + value
+	}
+	name, ok := importName(call.File, zapPkg)
+	if !ok {
+		name = path.Base(zapPkg)
+	}
+	return name + ".String(" + key + ", " + value + ")"
+}
+
+// FieldImport returns go.uber.org/zap if the fields formatted for the call
+// need it to be imported.
+func (z Zap) FieldImport(call CallContext) (string, bool) {
+	if _, ok := importName(call.File, zapPkg); ok || isSugared(call) {
+		return "", false
+	}
+	return zapPkg, true
+}
+
+// isSugared reports whether the call takes loosely typed key/value pairs,
+// as the methods of the SugaredLogger do, rather than fields.
+func isSugared(call CallContext) bool {
+	params := call.Signature.Params()
+	elem := params.At(params.Len() - 1).Type().(*types.Slice).Elem()
+	_, isInterface := elem.Underlying().(*types.Interface)
+	return isInterface
+}
+
+// isZapField reports whether typ is go.uber.org/zap/zapcore.Field, including
+// through the zap.Field alias, which denotes the same named type.
+func isZapField(typ types.Type) bool {
+	return typ != nil && isNamedType(typ, zapcorePkg, "Field")
+}
+
+var _ FieldChecker = (*Zap)(nil)
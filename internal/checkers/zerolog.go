@@ -0,0 +1,89 @@
+package checkers
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const zerologPkg = "github.com/rs/zerolog"
+
+// zerologEventEnds are the methods sending a zerolog event.
+var zerologEventEnds = []string{"Msg", "Msgf", "MsgFunc", "Send"}
+
+type Zerolog struct {
+	General
+}
+
+// IsChainType reports whether typ is an event, or the context of a logger
+// returned by With.
+func (z Zerolog) IsChainType(typ types.Type) bool {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		return isNamedType(ptr.Elem(), zerologPkg, "Event")
+	}
+	return isNamedType(typ, zerologPkg, "Context")
+}
+
+func (z Zerolog) IsChainEnd(fn *types.Func) bool {
+	for _, name := range zerologEventEnds {
+		if isFunc(fn, zerologPkg, "Event", name) {
+			return true
+		}
+	}
+	return isFunc(fn, zerologPkg, "Context", "Logger")
+}
+
+func (z Zerolog) SendChain(typ types.Type) (string, bool) {
+	ptr, ok := typ.(*types.Pointer)
+	if !ok || !isNamedType(ptr.Elem(), zerologPkg, "Event") {
+		return "", false
+	}
+	return ".Send()", true
+}
+
+// ChainFields returns the call of a field method of an event or a context,
+// and the entries of a map literal passed to Fields.
+func (z Zerolog) ChainFields(pass *analysis.Pass, call *ast.CallExpr) ([]ast.Expr, bool) {
+	fn, _ := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if fn != nil && fn.Name() == "Fields" && isChainLink(z, fn) {
+		return mapLiteralEntries(pass, call.Args[0])
+	}
+	return []ast.Expr{call}, true
+}
+
+// FieldKeyValue returns the key and value added by a field method, which
+// takes the key first, like Str(key, val string), or by a map entry.
+func (z Zerolog) FieldKeyValue(pass *analysis.Pass, field ast.Expr) (key, value ast.Expr, ok bool) {
+	switch field := field.(type) {
+	case *ast.KeyValueExpr:
+		return field.Key, field.Value, true
+	case *ast.CallExpr:
+		fn, _ := typeutil.Callee(pass.TypesInfo, field).(*types.Func)
+		if !isChainLink(z, fn) {
+			return nil, nil, false
+		}
+		params := fn.Type().(*types.Signature).Params()
+		if params.Len() != 2 || params.At(0).Name() != "key" {
+			return nil, nil, false
+		}
+		return field.Args[0], field.Args[1], true
+	}
+	return nil, nil, false
+}
+
+func (z Zerolog) FormatFields(_ CallContext, keyValues []string) string {
+	var b strings.Builder
+	for i := 0; i+1 < len(keyValues); i += 2 {
+		b.WriteString(".Str(" + keyValues[i] + ", " + keyValues[i+1] + ")")
+	}
+	return b.String()
+}
+
+func (z Zerolog) FormatContext(ctx string) string {
+	return ".Ctx(" + ctx + ")"
+}
+
+var _ ChainChecker = (*Zerolog)(nil)
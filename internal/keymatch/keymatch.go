@@ -0,0 +1,199 @@
+package keymatch
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type Mode int
+
+const (
+	Exact  Mode = iota // key equals the value
+	Fold               // key equals the value under Unicode case-folding
+	Prefix             // key starts with the value
+	Regexp             // key matches the regular expression
+)
+
+// modePrefixes maps the spec prefix of each mode, e.g. "icase:trace_id".
+// A spec without a known prefix matches exactly.
+var modePrefixes = map[string]Mode{
+	"exact":  Exact,
+	"icase":  Fold,
+	"prefix": Prefix,
+	"regex":  Regexp,
+}
+
+var (
+	// DefaultTraceKeys follows the key spellings used by OpenTelemetry, W3C
+	// trace context and Google Cloud structured logging.
+	DefaultTraceKeys = MustParse(
+		"icase:traceId",
+		"icase:trace_id",
+		"icase:trace.id",
+		"icase:trace-id",
+		"logging.googleapis.com/trace",
+	)
+	DefaultSpanKeys = MustParse(
+		"icase:spanId",
+		"icase:span_id",
+		"icase:span.id",
+		"icase:span-id",
+		"logging.googleapis.com/spanId",
+	)
+	DefaultTraceFlagKeys = MustParse(
+		"icase:traceFlags",
+		"icase:trace_flags",
+		"icase:trace.flags",
+		"icase:sampled",
+		"logging.googleapis.com/trace_sampled",
+	)
+)
+
+type Matcher struct {
+	Mode  Mode
+	Value string
+
+	re *regexp.Regexp
+}
+
+func ParseMatcher(spec string) (Matcher, error) {
+	m := Matcher{Mode: Exact, Value: spec}
+	if i := strings.IndexByte(spec, ':'); i > 0 {
+		if mode, ok := modePrefixes[spec[:i]]; ok {
+			m.Mode, m.Value = mode, spec[i+1:]
+		}
+	}
+	if m.Value == "" {
+		return Matcher{}, fmt.Errorf("empty key in %q", spec)
+	}
+
+	if m.Mode == Regexp {
+		re, err := regexp.Compile(m.Value)
+		if err != nil {
+			return Matcher{}, fmt.Errorf("invalid regex in %q: %w", spec, err)
+		}
+		m.re = re
+	}
+	return m, nil
+}
+
+func (m Matcher) Match(key string) bool {
+	switch m.Mode {
+	case Fold:
+		return strings.EqualFold(key, m.Value)
+	case Prefix:
+		return strings.HasPrefix(key, m.Value)
+	case Regexp:
+		return m.re.MatchString(key)
+	default:
+		return key == m.Value
+	}
+}
+
+func (m Matcher) String() string {
+	for prefix, mode := range modePrefixes {
+		if mode == m.Mode && mode != Exact {
+			return prefix + ":" + m.Value
+		}
+	}
+	return m.Value
+}
+
+// List is a set of accepted keys, any of which may match.
+type List []Matcher
+
+func Parse(specs ...string) (List, error) {
+	l := make(List, 0, len(specs))
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+
+		m, err := ParseMatcher(spec)
+		if err != nil {
+			return nil, err
+		}
+		l = append(l, m)
+	}
+	return l, nil
+}
+
+// MustParse is like Parse but panics on invalid specs, for use in
+// package level defaults.
+func MustParse(specs ...string) List {
+	l, err := Parse(specs...)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+func (l List) Match(key string) bool {
+	for _, m := range l {
+		if m.Match(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Canonical returns the first literal (exact or case-insensitive) key of
+// the list, which is the key inserted by suggested fixes.
+func (l List) Canonical() (string, bool) {
+	for _, m := range l {
+		if m.Mode == Exact || m.Mode == Fold {
+			return m.Value, true
+		}
+	}
+	return "", false
+}
+
+// Set implements flag.Value interface.
+func (l *List) Set(v string) error {
+	parsed, err := Parse(splitSpecs(v)...)
+	if err != nil {
+		return err
+	}
+	*l = parsed
+	return nil
+}
+
+// String implements flag.Value interface
+func (l List) String() string {
+	specs := make([]string, len(l))
+	for i, m := range l {
+		specs[i] = m.String()
+		// A regex extends to the next spec with a mode prefix.
+		if i > 0 && l[i-1].Mode == Regexp && m.Mode == Exact {
+			specs[i] = "exact:" + m.Value
+		}
+	}
+	return strings.Join(specs, ",")
+}
+
+// splitSpecs splits the comma-separated specs of v. The regex of a regex
+// spec may contain commas, as in "regex:^trace(id|_id){1,2}$", and extends
+// to the next comma followed by a mode prefix.
+func splitSpecs(v string) []string {
+	var specs []string
+	for _, part := range strings.Split(v, ",") {
+		if n := len(specs); n > 0 && isRegexSpec(specs[n-1]) && !hasModePrefix(part) {
+			specs[n-1] += "," + part
+			continue
+		}
+		specs = append(specs, part)
+	}
+	return specs
+}
+
+func isRegexSpec(spec string) bool {
+	return strings.HasPrefix(strings.TrimSpace(spec), "regex:")
+}
+
+func hasModePrefix(spec string) bool {
+	prefix, _, ok := strings.Cut(strings.TrimSpace(spec), ":")
+	_, isMode := modePrefixes[prefix]
+	return ok && isMode
+}
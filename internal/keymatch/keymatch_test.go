@@ -0,0 +1,134 @@
+package keymatch
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultTraceKeys(t *testing.T) {
+	t.Parallel()
+
+	for _, key := range []string{"traceId", "traceID", "trace_id", "trace.id", "Trace-Id", "logging.googleapis.com/trace"} {
+		assert.True(t, DefaultTraceKeys.Match(key), key)
+	}
+	for _, key := range []string{"trace", "traceback", "stacktrace", "spanId", "logging.googleapis.com/spanId"} {
+		assert.False(t, DefaultTraceKeys.Match(key), key)
+	}
+
+	key, ok := DefaultTraceKeys.Canonical()
+	assert.True(t, ok)
+	assert.Equal(t, "traceId", key)
+}
+
+func TestMatcher(t *testing.T) {
+	testCases := []struct {
+		name      string
+		spec      string
+		wantError string
+		match     []string
+		noMatch   []string
+	}{
+		{
+			name:    "exact",
+			spec:    "traceId",
+			match:   []string{"traceId"},
+			noMatch: []string{"traceID", "traceId2"},
+		},
+		{
+			name:    "exact-prefix",
+			spec:    "exact:trace_id",
+			match:   []string{"trace_id"},
+			noMatch: []string{"TRACE_ID"},
+		},
+		{
+			name:    "icase",
+			spec:    "icase:traceId",
+			match:   []string{"traceId", "TRACEID"},
+			noMatch: []string{"trace_id"},
+		},
+		{
+			name:    "prefix",
+			spec:    "prefix:x-b3-",
+			match:   []string{"x-b3-traceid", "x-b3-"},
+			noMatch: []string{"X-B3-TRACEID"},
+		},
+		{
+			name:    "regex",
+			spec:    "regex:^trace[-_.]?id$",
+			match:   []string{"traceid", "trace-id"},
+			noMatch: []string{"stacktrace-id"},
+		},
+		{
+			name:    "unknown-mode-is-literal",
+			spec:    "dd:trace_id",
+			match:   []string{"dd:trace_id"},
+			noMatch: []string{"trace_id"},
+		},
+		{
+			name:      "empty",
+			spec:      "icase:",
+			wantError: `empty key in "icase:"`,
+		},
+		{
+			name:      "bad-regex",
+			spec:      "regex:(",
+			wantError: "invalid regex in \"regex:(\": error parsing regexp: missing closing ): `(`",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := ParseMatcher(tc.spec)
+			if tc.wantError != "" {
+				assert.EqualError(t, err, tc.wantError)
+				return
+			}
+			require.NoError(t, err)
+			for _, key := range tc.match {
+				assert.True(t, m.Match(key), key)
+			}
+			for _, key := range tc.noMatch {
+				assert.False(t, m.Match(key), key)
+			}
+		})
+	}
+}
+
+func TestList_Flag(t *testing.T) {
+	t.Parallel()
+
+	l := append(List{}, DefaultTraceKeys...)
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&l, "tracekeys", "")
+
+	err := fs.Parse([]string{"-tracekeys= regex:^x-trace,exact:trace_id ,"})
+	require.NoError(t, err)
+	assert.Equal(t, "regex:^x-trace,exact:trace_id", l.String())
+	assert.True(t, l.Match("x-trace-id"))
+	assert.False(t, l.Match("traceId"))
+
+	key, ok := l.Canonical()
+	assert.True(t, ok)
+	assert.Equal(t, "trace_id", key)
+
+	err = fs.Parse([]string{"-tracekeys=regex:["})
+	assert.Error(t, err)
+
+	err = fs.Parse([]string{"-tracekeys=regex:^trace(id|_id){1,2}$,icase:traceId"})
+	require.NoError(t, err)
+	assert.Equal(t, "regex:^trace(id|_id){1,2}$,icase:traceId", l.String())
+	assert.True(t, l.Match("traceid_id"))
+	assert.True(t, l.Match("TRACEID"))
+
+	err = fs.Parse([]string{"-tracekeys=" + l.String()})
+	require.NoError(t, err)
+	assert.Len(t, l, 2)
+}
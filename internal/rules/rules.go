@@ -167,6 +167,44 @@ func ParseRules(lines []string) (result []Ruleset, err error) {
 		rulesByImport[packageImport] = append(rulesByImport[packageImport], pat)
 	}
 
+	return newRulesets(CustomRulesetName, rulesByImport), nil // NOTE(timonwong) Always "custom" for custom rule
+}
+
+// ParseNamedRules parses rules prefixed with the name of the ruleset they
+// belong to, for example "constructor github.com/go-logr/zapr.NewLogger".
+func ParseNamedRules(lines []string) (result []Ruleset, err error) {
+	var names []string
+	rulesByName := make(map[string]map[string][]FuncRule)
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("error parse rule at line %d: %w", i+1, ErrInvalidRule)
+		}
+		name := fields[0]
+		packageImport, pat, err := ParseFuncRule(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("error parse rule at line %d: %w", i+1, err)
+		}
+
+		if rulesByName[name] == nil {
+			names = append(names, name)
+			rulesByName[name] = make(map[string][]FuncRule)
+		}
+		rulesByName[name][packageImport] = append(rulesByName[name][packageImport], pat)
+	}
+
+	for _, name := range names {
+		result = append(result, newRulesets(name, rulesByName[name])...)
+	}
+	return result, nil
+}
+
+func newRulesets(name string, rulesByImport map[string][]FuncRule) (result []Ruleset) {
 	for packageImport, rules := range rulesByImport {
 		ruleIndicesByFuncName := make(map[string][]int, len(rules))
 		for idx, rule := range rules {
@@ -175,16 +213,35 @@ func ParseRules(lines []string) (result []Ruleset, err error) {
 		}
 
 		result = append(result, Ruleset{
-			Name:                  CustomRulesetName, // NOTE(timonwong) Always "custom" for custom rule
+			Name:                  name,
 			PackageImport:         packageImport,
 			Rules:                 rules,
 			ruleIndicesByFuncName: ruleIndicesByFuncName,
 		})
 	}
-	return result, nil
+	return result
 }
 
 func ParseRuleFile(r io.Reader) (result []Ruleset, err error) {
+	lines, err := readLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseRules(lines)
+}
+
+// ParseNamedRuleFile is like ParseRuleFile, for rules parsed by ParseNamedRules.
+func ParseNamedRuleFile(r io.Reader) (result []Ruleset, err error) {
+	lines, err := readLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseNamedRules(lines)
+}
+
+func readLines(r io.Reader) ([]string, error) {
 	// Rule files are relatively small, so read it into string slice first.
 	var lines []string
 
@@ -196,6 +253,5 @@ func ParseRuleFile(r io.Reader) (result []Ruleset, err error) {
 	if err := scanner.Err(); err != nil {
 		return nil, err
 	}
-
-	return ParseRules(lines)
+	return lines, nil
 }
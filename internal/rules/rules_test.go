@@ -16,6 +16,12 @@ func TestParseRuleFile_IOError(t *testing.T) {
 	assert.EqualError(t, err, "broken IO")
 }
 
+func TestParseNamedRuleFile_IOError(t *testing.T) {
+	r := iotest.ErrReader(errors.New("broken IO"))
+	_, err := ParseNamedRuleFile(r)
+	assert.EqualError(t, err, "broken IO")
+}
+
 func TestParseFuncRule(t *testing.T) {
 	testCases := []struct {
 		name              string
@@ -92,3 +98,29 @@ func TestReceiverTypeOf_InvalidType(t *testing.T) {
 	basicType := types.Universe.Lookup("byte").Type()
 	assert.Equal(t, "", receiverTypeOf(basicType))
 }
+
+func TestParseNamedRules(t *testing.T) {
+	t.Parallel()
+
+	rulesets, err := ParseNamedRules([]string{
+		"# Comment",
+		"constructor github.com/go-logr/zapr.NewLogger",
+		"",
+		"fromcontext github.com/go-logr/logr.FromContext",
+		"  constructor   github.com/go-logr/zapr.NewLoggerWithOptions  ",
+	})
+	require.NoError(t, err)
+	require.Len(t, rulesets, 2)
+
+	assert.Equal(t, "constructor", rulesets[0].Name)
+	assert.Equal(t, "github.com/go-logr/zapr", rulesets[0].PackageImport)
+	assert.Equal(t, []FuncRule{{FuncName: "NewLogger"}, {FuncName: "NewLoggerWithOptions"}}, rulesets[0].Rules)
+	assert.Equal(t, "fromcontext", rulesets[1].Name)
+	assert.Equal(t, "github.com/go-logr/logr", rulesets[1].PackageImport)
+
+	_, err = ParseNamedRules([]string{"github.com/go-logr/zapr.NewLogger"})
+	assert.EqualError(t, err, "error parse rule at line 1: invalid rule format")
+
+	_, err = ParseNamedRules([]string{"constructor", "constructor go.uber.org/zap"})
+	assert.EqualError(t, err, "error parse rule at line 1: invalid rule format")
+}
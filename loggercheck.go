@@ -4,31 +4,46 @@ import (
 	"flag"
 	"fmt"
 	"go/ast"
+	"go/token"
 	"go/types"
 	"os"
-	"sync"
+	"reflect"
 	"strings"
+	"sync"
 
 	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/ssa"
 	"golang.org/x/tools/go/types/typeutil"
 
 	"github.com/george-maroun/tracecheck/internal/checkers"
+	"github.com/george-maroun/tracecheck/internal/keymatch"
 	"github.com/george-maroun/tracecheck/internal/rules"
 	"github.com/george-maroun/tracecheck/internal/sets"
 )
 
-const Doc = `Checks key value pairs for common logger libraries (kitlog,klog,logr,zap).`
+const Doc = `Checks key value pairs for common logger libraries (kitlog,klog,logr,logrus,slog,zap,zerolog).`
 
 func NewAnalyzer(opts ...Option) *analysis.Analyzer {
 	l := newLoggerCheck(opts...)
+	// The facts are exported by a separate analyzer, which runs on every
+	// dependency, so that the checks and the SSA form only run on the
+	// packages being checked.
+	l.facts = &analysis.Analyzer{
+		Name:       "tracedloggers",
+		Doc:        "exports facts for the functions returning loggers which carry the trace keys",
+		Run:        l.exportFacts,
+		FactTypes:  []analysis.Fact{new(checkers.TracedLoggerFact)},
+		ResultType: reflect.TypeOf(checkers.TracedLoggers(nil)),
+	}
 	a := &analysis.Analyzer{
 		Name:     "loggercheck",
 		Doc:      Doc,
 		Flags:    *l.fs,
 		Run:      l.run,
-		Requires: []*analysis.Analyzer{inspect.Analyzer},
+		Requires: []*analysis.Analyzer{inspect.Analyzer, buildssa.Analyzer, l.facts},
 	}
 	return a
 }
@@ -36,40 +51,131 @@ func NewAnalyzer(opts ...Option) *analysis.Analyzer {
 type loggercheck struct {
 	fs *flag.FlagSet
 
-	disable          sets.StringSet // flag -disable
-	ruleFile         string         // flag -rulefile
-	requireStringKey bool           // flag -requirestringkey
-	noPrintfLike     bool           // flag -noprintflike
+	disable           sets.StringSet             // flag -disable
+	ruleFile          string                     // flag -rulefile
+	requireStringKey  bool                       // flag -requirestringkey
+	noPrintfLike      bool                       // flag -noprintflike
+	traceKeys         keymatch.List              // flag -tracekeys
+	spanKeys          keymatch.List              // flag -spankeys
+	traceFlagKeys     keymatch.List              // flag -traceflagkeys
+	requireTraceFlags bool                       // flag -requiretraceflags
+	verifyTraceValue  bool                       // flag -verifytracevalue
+	traceIDFuncs      sets.StringSet             // flag -traceidfuncs
+	contextSources    checkers.ContextSourceList // flag -contextsources
+	tracePolicy       sets.StringSet             // flag -tracepolicy
+	traceRuleFile     string                     // flag -tracerulefile
+	contextLogging    bool                       // flag -contextlogging
+	structLoggers     bool                       // flag -structloggers
+	freshContext      bool                       // flag -freshcontext
+	detachFuncs       sets.StringSet             // flag -detachfuncs
+	fixTemplateName   string                     // flag -fixtemplate
+	fixTemplateFile   string                     // flag -fixtemplatefile
 
 	rules                  []string         // used for external integration, for example golangci-lint
+	traceKeyRules          []string         // used for external integration, for example golangci-lint
+	spanKeyRules           []string         // used for external integration, for example golangci-lint
+	traceFlagKeyRules      []string         // used for external integration, for example golangci-lint
+	contextSourceRules     []string         // used for external integration, for example golangci-lint
+	traceRules             []string         // used for external integration, for example golangci-lint
+	fixTemplateRules       []string         // used for external integration, for example golangci-lint
+	traceRulesetList       []rules.Ruleset  // populate at runtime
 	rulesetList            []rules.Ruleset  // populate at runtime
 	rulesetIndicesByImport map[string][]int // ruleset index, populate at runtime
-	mu sync.Mutex
-	CallToFile map[*ast.CallExpr]*ast.File
+	optionErr              error            // error of the options, returned by processConfig
+	mu                     sync.Mutex
+	configured             bool                 // whether processConfig has run
+	configErr              error                // error of processConfig
+	fixTemplate            checkers.FixTemplate // populate at runtime
+	facts                  *analysis.Analyzer   // exports the facts of the traced loggers
 }
 
 func newLoggerCheck(opts ...Option) *loggercheck {
 	fs := flag.NewFlagSet("loggercheck", flag.ExitOnError)
 	l := &loggercheck{
-		fs:          fs,
-		disable:     sets.NewString("kitlog"),
-		rulesetList: append([]rules.Ruleset{}, staticRuleList...), // ensure we make a clone of static rules first
-		// CalltoFile allows us to access the current file in the checker
-		CallToFile: make(map[*ast.CallExpr]*ast.File),
+		fs:               fs,
+		disable:          sets.NewString("kitlog"),
+		traceKeys:        append(keymatch.List{}, keymatch.DefaultTraceKeys...),
+		spanKeys:         append(keymatch.List{}, keymatch.DefaultSpanKeys...),
+		traceFlagKeys:    append(keymatch.List{}, keymatch.DefaultTraceFlagKeys...),
+		contextSources:   append(checkers.ContextSourceList{}, checkers.DefaultContextSources...),
+		tracePolicy:      sets.NewString(checkers.TracePolicyConstructor),
+		detachFuncs:      sets.NewString(checkers.DefaultDetachFuncs...),
+		fixTemplateName:  checkers.FixTemplateOpenTelemetry,
+		rulesetList:      append([]rules.Ruleset{}, staticRuleList...), // ensure we make a clone of static rules first
+		traceRulesetList: append([]rules.Ruleset{}, staticTraceRuleList...),
 	}
 
 	fs.StringVar(&l.ruleFile, "rulefile", "", "path to a file contains a list of rules")
-	fs.Var(&l.disable, "disable", "comma-separated list of disabled logger checker (kitlog,klog,logr,zap)")
+	fs.Var(&l.disable, "disable", "comma-separated list of disabled logger checker (kitlog,klog,logr,logrus,slog,zap,zerolog)")
 	fs.BoolVar(&l.requireStringKey, "requirestringkey", false, "require all logging keys to be inlined constant strings")
 	fs.BoolVar(&l.noPrintfLike, "noprintflike", false, "require printf-like format specifier not present in args")
+	fs.Var(&l.traceKeys, "tracekeys", "comma-separated list of accepted trace keys, each optionally prefixed with a match mode (exact:,icase:,prefix:,regex:)")
+	fs.Var(&l.spanKeys, "spankeys", "comma-separated list of accepted span keys, each optionally prefixed with a match mode (exact:,icase:,prefix:,regex:)")
+	fs.Var(&l.traceFlagKeys, "traceflagkeys", "comma-separated list of accepted trace flags keys, each optionally prefixed with a match mode (exact:,icase:,prefix:,regex:)")
+	fs.BoolVar(&l.requireTraceFlags, "requiretraceflags", false, "require trace flags alongside traceId and spanId in logging keys")
+	fs.BoolVar(&l.verifyTraceValue, "verifytracevalue", false, "require traceId values to be derived from trace.SpanFromContext(ctx)")
+	fs.Var(&l.tracePolicy, "tracepolicy", "comma-separated list of trace policies selecting the logging calls which require trace keys ("+strings.Join(checkers.TracePolicies, ",")+")")
+	fs.StringVar(&l.traceRuleFile, "tracerulefile", "", "path to a file contains a list of trace rules, each prefixed with the trace policy it extends")
+	fs.Var(&l.contextSources, "contextsources", "comma-separated list of parameter types carrying the context of functions without a context.Context parameter, with the path to the context, e.g. (*net/http.Request).Context()")
+	fs.BoolVar(&l.contextLogging, "contextlogging", false, "accept calls passing the function's ctx to a context-aware logging method such as InfoContext, and require them where available")
+	fs.BoolVar(&l.structLoggers, "structloggers", false, "require trace keys on calls logging through a logger held by a struct, such as s.log, in functions with a context")
+	fs.BoolVar(&l.freshContext, "freshcontext", false, "report context.Background() and context.TODO() in functions with a context, which drop its span")
+	fs.Var(&l.detachFuncs, "detachfuncs", "comma-separated list of functions detaching from the function's context on purpose, in which, or to which, fresh contexts are accepted by -freshcontext")
+	fs.StringVar(&l.fixTemplateName, "fixtemplate", l.fixTemplateName, "fix template of the code inserted by fixes for the tracing library in use ("+strings.Join(checkers.FixTemplateNames, ",")+")")
+	fs.StringVar(&l.fixTemplateFile, "fixtemplatefile", "", "path to a file overriding the fields of the fix template, one \"field: value\" per line")
+	fs.Var(&l.traceIDFuncs, "traceidfuncs", "comma-separated list of functions returning the trace id of their context argument, e.g. example.com/tracing.TraceID")
 
 	for _, opt := range opts {
 		opt(l)
 	}
+	l.optionErr = l.parseOptions()
 
 	return l
 }
 
+// parseOptions parses the lists given by options. They are parsed before
+// the flags, which take precedence over options as for every setting.
+func (l *loggercheck) parseOptions() error {
+	if len(l.traceKeyRules) > 0 {
+		traceKeys, err := keymatch.Parse(l.traceKeyRules...)
+		if err != nil {
+			return fmt.Errorf("failed to parse trace keys: %w", err)
+		}
+		l.traceKeys = traceKeys
+	}
+	if len(l.spanKeyRules) > 0 {
+		spanKeys, err := keymatch.Parse(l.spanKeyRules...)
+		if err != nil {
+			return fmt.Errorf("failed to parse span keys: %w", err)
+		}
+		l.spanKeys = spanKeys
+	}
+	if len(l.traceFlagKeyRules) > 0 {
+		traceFlagKeys, err := keymatch.Parse(l.traceFlagKeyRules...)
+		if err != nil {
+			return fmt.Errorf("failed to parse trace flags keys: %w", err)
+		}
+		l.traceFlagKeys = traceFlagKeys
+	}
+	if len(l.contextSourceRules) > 0 {
+		contextSources, err := checkers.ParseContextSources(l.contextSourceRules...)
+		if err != nil {
+			return fmt.Errorf("failed to parse context sources: %w", err)
+		}
+		l.contextSources = contextSources
+	}
+	return nil
+}
+
+func isTracePolicy(name string) bool {
+	for _, policy := range checkers.TracePolicies {
+		if name == policy {
+			return true
+		}
+	}
+	return false
+}
+
 func (l *loggercheck) isCheckerDisabled(name string) bool {
 	return l.disable.Has(name)
 }
@@ -99,12 +205,6 @@ func (l *loggercheck) getCheckerForFunc(fn *types.Func) checkers.Checker {
 			continue
 		}
 
-		// Only check functions where `WithValues` is called.
-		fullFuncName := fn.FullName()
-		if !strings.HasSuffix(fullFuncName, "WithValues") {
-				continue
-		}
-
 		if !rs.Match(fn) {
 			continue
 		}
@@ -119,14 +219,37 @@ func (l *loggercheck) getCheckerForFunc(fn *types.Func) checkers.Checker {
 	return nil
 }
 
-func (l *loggercheck) checkLoggerArguments(pass *analysis.Pass, call *ast.CallExpr) {
+func (l *loggercheck) checkLoggerArguments(pass *analysis.Pass, call *ast.CallExpr, stack []ast.Node, ssaCalls map[token.Pos]ssa.CallInstruction, reported checkers.ReportedEdits, traced checkers.TracedLoggers) {
 	fn, _ := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
 	if fn == nil {
 		return // function pointer is not supported
 	}
 
-	sig, ok := fn.Type().(*types.Signature)
-	if !ok || !sig.Variadic() {
+	checker := l.getCheckerForFunc(fn)
+	if checker == nil {
+		return
+	}
+
+	sig := fn.Type().(*types.Signature)
+	callCtx := checkers.CallContext{
+		Expr:      call,
+		Func:      fn,
+		Signature: sig,
+		File:      stack[0].(*ast.File),
+		Stack:     stack,
+		SSA:       ssaCalls[call.Lparen],
+		Reported:  reported,
+		Traced:    traced,
+	}
+	cfg := l.checkerConfig()
+
+	// Fluent loggers log the fields of a chain of calls.
+	if chainChecker, ok := checker.(checkers.ChainChecker); ok {
+		checkers.ExecuteChainChecker(chainChecker, pass, callCtx, cfg)
+		return
+	}
+
+	if !sig.Variadic() {
 		return // not variadic
 	}
 
@@ -135,28 +258,62 @@ func (l *loggercheck) checkLoggerArguments(pass *analysis.Pass, call *ast.CallEx
 		return
 	}
 
-	checker := l.getCheckerForFunc(fn)
-	if checker == nil {
+	checkers.ExecuteChecker(checker, pass, callCtx, cfg)
+}
+
+func (l *loggercheck) checkFreshContext(pass *analysis.Pass, call *ast.CallExpr, stack []ast.Node, reported checkers.ReportedEdits) {
+	fn, _ := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if fn == nil {
 		return
 	}
 
-	// Retrieve the current file from the map
-	file := l.CallToFile[call]
-
-	checkers.ExecuteChecker(checker, pass, checkers.CallContext{
+	checkers.CheckFreshContext(pass, checkers.CallContext{
 		Expr:      call,
 		Func:      fn,
-		Signature: sig,
-		File:      file, // pass the file here
-	}, checkers.Config{
-		RequireStringKey: l.requireStringKey,
-		NoPrintfLike:     l.noPrintfLike,
-	})
+		Signature: fn.Type().(*types.Signature),
+		File:      stack[0].(*ast.File),
+		Stack:     stack,
+		Reported:  reported,
+	}, l.checkerConfig())
+}
+
+// checkerConfig returns the configuration of the checkers from the flags.
+func (l *loggercheck) checkerConfig() checkers.Config {
+	return checkers.Config{
+		RequireStringKey:  l.requireStringKey,
+		NoPrintfLike:      l.noPrintfLike,
+		TraceKeys:         l.traceKeys,
+		SpanKeys:          l.spanKeys,
+		TraceFlagKeys:     l.traceFlagKeys,
+		RequireTraceFlags: l.requireTraceFlags,
+		VerifyTraceValue:  l.verifyTraceValue,
+		TraceIDFuncs:      l.traceIDFuncs,
+		ContextSources:    l.contextSources,
+		TracePolicy:       l.tracePolicy,
+		TraceRules:        l.traceRulesetList,
+		ContextLogging:    l.contextLogging,
+		StructLoggers:     l.structLoggers,
+		DetachFuncs:       l.detachFuncs,
+		FixTemplate:       l.fixTemplate,
+		CheckerFor:        l.getCheckerForFunc,
+	}
 }
 
+// processConfig parses the configuration once, as run and exportFacts run
+// concurrently on many packages.
 func (l *loggercheck) processConfig() error {
 	l.mu.Lock() // lock
 	defer l.mu.Unlock()
+	if !l.configured {
+		l.configured, l.configErr = true, l.parseConfig()
+	}
+	return l.configErr
+}
+
+func (l *loggercheck) parseConfig() error {
+	if l.optionErr != nil {
+		return l.optionErr
+	}
 	if l.ruleFile != "" { // flags takes precedence over configs
 		f, err := os.Open(l.ruleFile)
 		if err != nil {
@@ -177,6 +334,56 @@ func (l *loggercheck) processConfig() error {
 		l.rulesetList = append(l.rulesetList, custom...)
 	}
 
+	if l.traceRuleFile != "" { // flags takes precedence over configs
+		f, err := os.Open(l.traceRuleFile)
+		if err != nil {
+			return fmt.Errorf("failed to open trace rule file: %w", err)
+		}
+		defer f.Close()
+
+		custom, err := rules.ParseNamedRuleFile(f)
+		if err != nil {
+			return fmt.Errorf("failed to parse trace rule file: %w", err)
+		}
+		l.traceRulesetList = append(append([]rules.Ruleset{}, staticTraceRuleList...), custom...)
+	} else if len(l.traceRules) > 0 {
+		custom, err := rules.ParseNamedRules(l.traceRules)
+		if err != nil {
+			return fmt.Errorf("failed to parse trace rules: %w", err)
+		}
+		l.traceRulesetList = append(append([]rules.Ruleset{}, staticTraceRuleList...), custom...)
+	}
+	for _, name := range l.tracePolicy.List() {
+		if !isTracePolicy(name) {
+			return fmt.Errorf("unknown trace policy %q", name)
+		}
+	}
+	for _, rs := range l.traceRulesetList {
+		if !isTracePolicy(rs.Name) {
+			return fmt.Errorf("unknown trace policy %q in trace rules", rs.Name)
+		}
+	}
+
+	if err := l.parseFixTemplate(); err != nil {
+		return err
+	}
+	// The keys inserted by the fixes are accepted by the checks.
+	l.traceKeys = acceptKey(l.traceKeys, l.fixTemplate.TraceKey)
+	l.spanKeys = acceptKey(l.spanKeys, l.fixTemplate.SpanKey)
+	l.traceFlagKeys = acceptKey(l.traceFlagKeys, l.fixTemplate.TraceFlagsKey)
+
+	// Without a key in the fix template, the fixes insert the first literal
+	// key, which patterns cannot give.
+	if _, ok := l.traceKeys.Canonical(); !ok && l.fixTemplate.TraceKey == "" {
+		return fmt.Errorf("trace keys %q have no exact or icase key to insert", l.traceKeys.String())
+	}
+	if _, ok := l.spanKeys.Canonical(); !ok && l.fixTemplate.SpanKey == "" {
+		return fmt.Errorf("span keys %q have no exact or icase key to insert", l.spanKeys.String())
+	}
+	if _, ok := l.traceFlagKeys.Canonical(); !ok && l.fixTemplate.TraceFlagsKey == "" {
+		return fmt.Errorf("trace flags keys %q have no exact or icase key to insert", l.traceFlagKeys.String())
+	}
+
 	// Build index
 	indices := make(map[string][]int)
 	for i, rs := range l.rulesetList {
@@ -187,52 +394,105 @@ func (l *loggercheck) processConfig() error {
 	return nil
 }
 
+// parseFixTemplate sets the fix template to the built-in one named by
+// -fixtemplate, with the fields of the fix template file, or rules, applied.
+func (l *loggercheck) parseFixTemplate() error {
+	base, ok := checkers.FixTemplates[l.fixTemplateName]
+	if !ok {
+		return fmt.Errorf("unknown fix template %q", l.fixTemplateName)
+	}
+
+	l.fixTemplate = base
+	if l.fixTemplateFile != "" { // flags takes precedence over configs
+		f, err := os.Open(l.fixTemplateFile)
+		if err != nil {
+			return fmt.Errorf("failed to open fix template file: %w", err)
+		}
+		defer f.Close()
+
+		l.fixTemplate, err = checkers.ParseFixTemplateFile(base, f)
+		if err != nil {
+			return fmt.Errorf("failed to parse fix template file: %w", err)
+		}
+	} else if len(l.fixTemplateRules) > 0 {
+		var err error
+		l.fixTemplate, err = checkers.ParseFixTemplate(base, l.fixTemplateRules)
+		if err != nil {
+			return fmt.Errorf("failed to parse fix template: %w", err)
+		}
+	}
+	return l.fixTemplate.Validate()
+}
+
+// acceptKey returns keys, with key matched exactly if it does not match yet.
+func acceptKey(keys keymatch.List, key string) keymatch.List {
+	if key == "" || keys.Match(key) {
+		return keys
+	}
+	return append(keys, keymatch.Matcher{Mode: keymatch.Exact, Value: key})
+}
+
 func (l *loggercheck) run(pass *analysis.Pass) (interface{}, error) {
 	err := l.processConfig()
 	if err != nil {
 		return nil, err
 	}
 
+	// The calls and edits are recorded per pass, as passes run concurrently.
+	ssaCalls := ssaCallsByPos(pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA))
+	reported := make(checkers.ReportedEdits)
+
+	traced := pass.ResultOf[l.facts].(checkers.TracedLoggers)
+
 	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	nodeFilter := []ast.Node{
 		(*ast.CallExpr)(nil),
 	}
-	insp.Preorder(nodeFilter, func(node ast.Node) {
-		call := node.(*ast.CallExpr)
-
-		tokFile := pass.Fset.File(call.Pos())
-		if tokFile == nil {
-			return
+	insp.WithStack(nodeFilter, func(node ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
 		}
-
-		// Find the corresponding ast.File
-		var file *ast.File
-		for _, f := range pass.Files {
-			if pass.Fset.File(f.Pos()) == tokFile {
-				file = f
-				break
-			}
-		}
-
-		if file == nil {
-			return
-		}
-
-		// Save the current file to the map
-    // Lock the mutex before accessing the shared resource
-		l.mu.Lock()
-		l.CallToFile[call] = file
-		// Unlock it afterwards
-		defer l.mu.Unlock()
+		call := node.(*ast.CallExpr)
 
 		typ := pass.TypesInfo.Types[call.Fun].Type
 		if typ == nil {
 			// Skip checking functions with unknown type.
-			return
+			return true
 		}
 
-		l.checkLoggerArguments(pass, call)
+		l.checkLoggerArguments(pass, call, stack, ssaCalls, reported, traced)
+		if l.freshContext {
+			l.checkFreshContext(pass, call, stack, reported)
+		}
+		return true
 	})
 
 	return nil, nil
 }
+
+// exportFacts exports the facts of the functions of the package returning
+// loggers which carry the trace keys. It runs on every dependency of the
+// packages being checked, and leaves configuration errors to run.
+func (l *loggercheck) exportFacts(pass *analysis.Pass) (interface{}, error) {
+	if err := l.processConfig(); err != nil {
+		return checkers.TracedLoggers{}, nil
+	}
+
+	return checkers.ExportTracedLoggerFacts(pass, l.checkerConfig()), nil
+}
+
+// ssaCallsByPos indexes the calls of the package's functions, including
+// function literals, by the position of their opening parenthesis.
+func ssaCallsByPos(prog *buildssa.SSA) map[token.Pos]ssa.CallInstruction {
+	calls := make(map[token.Pos]ssa.CallInstruction)
+	for _, fn := range prog.SrcFuncs {
+		for _, b := range fn.Blocks {
+			for _, instr := range b.Instrs {
+				if call, ok := instr.(ssa.CallInstruction); ok {
+					calls[call.Common().Pos()] = call
+				}
+			}
+		}
+	}
+	return calls
+}
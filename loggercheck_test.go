@@ -2,6 +2,7 @@ package loggercheck_test
 
 import (
 	"testing"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/tools/go/analysis/analysistest"
@@ -28,6 +29,7 @@ func TestLinter(t *testing.T) {
 		name      string
 		patterns  string
 		flags     []string
+		options   []loggercheck.Option
 		wantError string
 	}{
 		{
@@ -35,12 +37,177 @@ func TestLinter(t *testing.T) {
 			patterns: "a/all",
 			flags:    []string{""},
 		},
+		{
+			name:     "requirestringkey",
+			patterns: "a/requirestringkey",
+			flags:    []string{"-requirestringkey"},
+		},
+		{
+			name:     "noprintflike",
+			patterns: "a/noprintflike",
+			flags:    []string{"-noprintflike"},
+		},
+		{
+			name:     "slog",
+			patterns: "a/slog",
+			flags:    []string{"-tracepolicy=constructor,withvalues,logcall"},
+		},
+		{
+			name:     "zaplogger",
+			patterns: "a/zaplogger",
+			flags:    []string{"-tracepolicy=constructor,withvalues,logcall"},
+		},
+		{
+			name:     "klog",
+			patterns: "a/klog",
+			flags:    []string{"-tracepolicy=constructor,fromcontext,logcall"},
+		},
+		{
+			name:     "kitlog",
+			patterns: "a/kitlog",
+			flags:    []string{"-disable=", "-tracepolicy=constructor,logcall"},
+		},
+		{
+			name:     "tracedlogger",
+			patterns: "a/tracedlogger",
+			flags:    []string{"-tracepolicy=logcall"},
+		},
+		{
+			name:     "loggerflow",
+			patterns: "a/loggerflow",
+			flags:    []string{"-tracepolicy=withvalues,logcall"},
+		},
+		{
+			name:     "structlogger",
+			patterns: "a/structlogger",
+			flags:    []string{"-structloggers"},
+		},
+		{
+			name:     "freshcontext",
+			patterns: "a/freshcontext",
+			flags:    []string{"-freshcontext", "-detachfuncs=a/freshcontext.detach"},
+		},
+		{
+			name:     "contextlogging",
+			patterns: "a/contextlogging",
+			flags:    []string{"-contextlogging", "-tracepolicy=logcall"},
+		},
+		{
+			name:     "zerolog",
+			patterns: "a/zerolog",
+			flags:    []string{"-tracepolicy=constructor,fromcontext,withvalues", "-requirestringkey", "-noprintflike"},
+		},
+		{
+			name:     "logrus",
+			patterns: "a/logrus",
+			flags:    []string{"-requirestringkey", "-noprintflike"},
+		},
+		{
+			name:     "tracekey",
+			patterns: "a/tracekey",
+		},
+		{
+			name:     "contextparam",
+			patterns: "a/contextparam",
+		},
+		{
+			name:     "contextsource",
+			patterns: "a/contextsource",
+			flags: []string{
+				"-contextsources=(*net/http.Request).Context(),(*a/contextsource/web.Context).Request.Context(),(a/contextsource/web.ServerStream).Context()",
+				"-verifytracevalue",
+			},
+		},
+		{
+			name:      "contextsource-invalid",
+			patterns:  "a/contextsource",
+			options:   []loggercheck.Option{loggercheck.WithContextSources([]string{"net/http.Request.Context()"})},
+			wantError: "failed to parse context sources",
+		},
+		{
+			name:     "tracepolicy-constructor",
+			patterns: "a/tracepolicy/constructor",
+			options:  []loggercheck.Option{loggercheck.WithTraceRules([]string{"constructor a/tracepolicy/factory.NewLogger"})},
+		},
+		{
+			name:     "tracepolicy-fromcontext",
+			patterns: "a/tracepolicy/fromcontext",
+			flags:    []string{"-tracepolicy=fromcontext"},
+		},
+		{
+			name:     "tracepolicy-logcall",
+			patterns: "a/tracepolicy/logcall",
+			flags:    []string{"-tracepolicy=logcall"},
+		},
+		{
+			name:      "tracepolicy-invalid",
+			patterns:  "a/tracepolicy/logcall",
+			flags:     []string{"-tracepolicy=constructor,everything"},
+			wantError: `unknown trace policy "everything"`,
+		},
+		{
+			name:      "tracerules-invalid",
+			patterns:  "a/tracepolicy/logcall",
+			options:   []loggercheck.Option{loggercheck.WithTraceRules([]string{"everything a/tracepolicy/factory.NewLogger"})},
+			wantError: `unknown trace policy "everything" in trace rules`,
+		},
+		{
+			name:     "tracekeys-custom",
+			patterns: "a/tracekeys",
+			flags:    []string{"-tracekeys=prefix:x-b3-,exact:request_trace"},
+		},
+		{
+			name:      "tracekeys-invalid",
+			patterns:  "a/tracekeys",
+			options:   []loggercheck.Option{loggercheck.WithTraceKeys([]string{"regex:("})},
+			wantError: "failed to parse trace keys",
+		},
+		{
+			name:      "tracekeys-patterns-only",
+			patterns:  "a/tracekeys",
+			flags:     []string{"-tracekeys=prefix:x-b3-,regex:^request_trace$"},
+			wantError: "have no exact or icase key to insert",
+		},
+		{
+			name:     "tracekeys-flag-precedence",
+			patterns: "a/tracekeys",
+			flags:    []string{"-tracekeys=prefix:x-b3-,exact:request_trace"},
+			options:  []loggercheck.Option{loggercheck.WithTraceKeys([]string{"traceId"})},
+		},
+		{
+			name:     "tracekeys-patterns-template-key",
+			patterns: "a/tracekeys",
+			flags:    []string{"-tracekeys=prefix:x-b3-,regex:^request_trace$"},
+			options:  []loggercheck.Option{loggercheck.WithFixTemplateRules([]string{"traceKey: request_trace"})},
+		},
+		{
+			name:      "fixtemplate-unknown",
+			patterns:  "a/tracekeys",
+			flags:     []string{"-fixtemplate=zipkin"},
+			wantError: `unknown fix template "zipkin"`,
+		},
+		{
+			name:      "fixtemplate-invalid",
+			patterns:  "a/tracekeys",
+			options:   []loggercheck.Option{loggercheck.WithFixTemplate("helper")},
+			wantError: "the import path of {tracelog} is not set",
+		},
+		{
+			name:     "traceflags",
+			patterns: "a/traceflags",
+			flags:    []string{"-requiretraceflags"},
+		},
+		{
+			name:     "tracevalue",
+			patterns: "a/tracevalue",
+			flags:    []string{"-verifytracevalue", "-traceidfuncs=a/tracevalue/tracing.TraceID"},
+		},
 	}
 
 	for _, tc := range testCases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			a := loggercheck.NewAnalyzer()
+			a := loggercheck.NewAnalyzer(tc.options...)
 			err := a.Flags.Parse(tc.flags)
 			require.NoError(t, err)
 
@@ -64,21 +231,126 @@ func TestLinterFix(t *testing.T) {
 	testdata := analysistest.TestData()
 
 	testCases := []struct {
-		name      string
-		dir  string
+		name    string
+		dir     string
+		flags   []string
+		options []loggercheck.Option
 	}{
 		{
-			name:     "fix_import",
-			dir: "a/fix_import",
+			name: "fix_import",
+			dir:  "a/fix_import",
+		},
+		{
+			name:  "fix_traceflags",
+			dir:   "a/fix_traceflags",
+			flags: []string{"-requiretraceflags"},
+		},
+		{
+			name: "fix_contextsource",
+			dir:  "a/fix_contextsource",
+		},
+		{
+			name: "fix_funclit",
+			dir:  "a/fix_funclit",
+		},
+		{
+			name:  "fix_contextlogging",
+			dir:   "a/fix_contextlogging",
+			flags: []string{"-contextlogging", "-tracepolicy=logcall"},
+		},
+		{
+			name:  "fix_zerolog",
+			dir:   "a/fix_zerolog",
+			flags: []string{"-tracepolicy=logcall,withvalues"},
+		},
+		{
+			name:  "fix_logrus",
+			dir:   "a/fix_logrus",
+			flags: []string{"-tracepolicy=logcall"},
+		},
+		{
+			name:  "fix_slog",
+			dir:   "a/fix_slog",
+			flags: []string{"-tracepolicy=logcall"},
+		},
+		{
+			name:  "fix_zap",
+			dir:   "a/fix_zap",
+			flags: []string{"-tracepolicy=logcall"},
+		},
+		{
+			name:  "fix_klog",
+			dir:   "a/fix_klog",
+			flags: []string{"-tracepolicy=fromcontext,logcall"},
+		},
+		{
+			name:  "fix_kitlog",
+			dir:   "a/fix_kitlog",
+			flags: []string{"-disable=", "-tracepolicy=withvalues,logcall"},
+		},
+		{
+			name:  "fix_structlogger",
+			dir:   "a/fix_structlogger",
+			flags: []string{"-structloggers"},
+		},
+		{
+			name:  "fix_freshcontext",
+			dir:   "a/fix_freshcontext",
+			flags: []string{"-freshcontext"},
+		},
+		{
+			name:  "fix_spanreuse",
+			dir:   "a/fix_spanreuse",
+			flags: []string{"-tracepolicy=logcall"},
+		},
+		{
+			name:  "fix_spanpos",
+			dir:   "a/fix_spanpos",
+			flags: []string{"-tracepolicy=logcall"},
+		},
+		{
+			name:  "fix_multiline",
+			dir:   "a/fix_multiline",
+			flags: []string{"-tracepolicy=withvalues"},
+		},
+		{
+			name:  "fix_imports",
+			dir:   "a/fix_imports",
+			flags: []string{"-tracepolicy=logcall"},
+		},
+		{
+			name:  "fix_fixtemplate_helper",
+			dir:   "a/fix_fixtemplate/helper",
+			flags: []string{"-tracepolicy=logcall", "-fixtemplate=helper", "-fixtemplatefile=testdata/fixtemplate-tracelog.txt"},
+		},
+		{
+			name:  "fix_fixtemplate_datadog",
+			dir:   "a/fix_fixtemplate/datadog",
+			flags: []string{"-tracepolicy=logcall"},
+			options: []loggercheck.Option{
+				loggercheck.WithFixTemplate("datadog"),
+				loggercheck.WithFixTemplateRules([]string{"import: a/fixtemplate/tracer", "span: a/fixtemplate/tracer.Span"}),
+			},
+		},
+		{
+			name:  "fix_fixtemplate_opencensus",
+			dir:   "a/fix_fixtemplate/opencensus",
+			flags: []string{"-tracepolicy=logcall", "-requiretraceflags"},
+			options: []loggercheck.Option{
+				loggercheck.WithFixTemplate("opencensus"),
+				loggercheck.WithFixTemplateRules([]string{"import: a/fixtemplate/octrace trace octrace ocTrace", "span: *a/fixtemplate/octrace.Span"}),
+			},
 		},
 	}
 
 	for _, tc := range testCases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			a := loggercheck.NewAnalyzer()
+			a := loggercheck.NewAnalyzer(tc.options...)
+			err := a.Flags.Parse(tc.flags)
+			require.NoError(t, err)
+
 			analysistest.RunWithSuggestedFixes(t, testdata, a, tc.dir)
 		})
 	}
 }
-
@@ -4,6 +4,9 @@ import (
 	"github.com/george-maroun/tracecheck/internal/sets"
 )
 
+// Option configures the analyzer, for external integration such as
+// golangci-lint. Flags take precedence over options: an option is ignored
+// when the flag of the same setting is given.
 type Option func(*loggercheck)
 
 func WithDisable(disable []string) Option {
@@ -29,3 +32,93 @@ func WithNoPrintfLike(noPrintfLike bool) Option {
 		l.noPrintfLike = noPrintfLike
 	}
 }
+
+func WithTraceKeys(traceKeys []string) Option {
+	return func(l *loggercheck) {
+		l.traceKeyRules = traceKeys
+	}
+}
+
+func WithSpanKeys(spanKeys []string) Option {
+	return func(l *loggercheck) {
+		l.spanKeyRules = spanKeys
+	}
+}
+
+func WithTraceFlagKeys(traceFlagKeys []string) Option {
+	return func(l *loggercheck) {
+		l.traceFlagKeyRules = traceFlagKeys
+	}
+}
+
+func WithRequireTraceFlags(requireTraceFlags bool) Option {
+	return func(l *loggercheck) {
+		l.requireTraceFlags = requireTraceFlags
+	}
+}
+
+func WithTracePolicy(tracePolicy []string) Option {
+	return func(l *loggercheck) {
+		l.tracePolicy = sets.NewString(tracePolicy...)
+	}
+}
+
+func WithTraceRules(traceRules []string) Option {
+	return func(l *loggercheck) {
+		l.traceRules = traceRules
+	}
+}
+
+func WithContextSources(contextSources []string) Option {
+	return func(l *loggercheck) {
+		l.contextSourceRules = contextSources
+	}
+}
+
+func WithVerifyTraceValue(verifyTraceValue bool) Option {
+	return func(l *loggercheck) {
+		l.verifyTraceValue = verifyTraceValue
+	}
+}
+
+func WithTraceIDFuncs(traceIDFuncs []string) Option {
+	return func(l *loggercheck) {
+		l.traceIDFuncs = sets.NewString(traceIDFuncs...)
+	}
+}
+
+func WithContextLogging(contextLogging bool) Option {
+	return func(l *loggercheck) {
+		l.contextLogging = contextLogging
+	}
+}
+
+func WithStructLoggers(structLoggers bool) Option {
+	return func(l *loggercheck) {
+		l.structLoggers = structLoggers
+	}
+}
+
+func WithFreshContext(freshContext bool) Option {
+	return func(l *loggercheck) {
+		l.freshContext = freshContext
+	}
+}
+
+func WithDetachFuncs(detachFuncs []string) Option {
+	return func(l *loggercheck) {
+		l.detachFuncs = sets.NewString(detachFuncs...)
+	}
+}
+
+func WithFixTemplate(fixTemplate string) Option {
+	return func(l *loggercheck) {
+		l.fixTemplateName = fixTemplate
+	}
+}
+
+func WithFixTemplateRules(fixTemplateRules []string) Option {
+	return func(l *loggercheck) {
+		l.fixTemplateRules = fixTemplateRules
+	}
+}
@@ -22,6 +22,7 @@ var (
 			"(k8s.io/klog/v2.Verbose).InfoS",
 			"(k8s.io/klog/v2.Verbose).InfoSDepth",
 			"(k8s.io/klog/v2.Verbose).ErrorS",
+			"k8s.io/klog/v2.LoggerWithValues",
 		}),
 		mustNewStaticRuleSet("zap", []string{
 			"(*go.uber.org/zap.SugaredLogger).With",
@@ -32,7 +33,73 @@ var (
 			"(*go.uber.org/zap.SugaredLogger).DPanicw",
 			"(*go.uber.org/zap.SugaredLogger).Panicw",
 			"(*go.uber.org/zap.SugaredLogger).Fatalw",
+			"(*go.uber.org/zap.Logger).With",
+			"(*go.uber.org/zap.Logger).Debug",
+			"(*go.uber.org/zap.Logger).Info",
+			"(*go.uber.org/zap.Logger).Warn",
+			"(*go.uber.org/zap.Logger).Error",
+			"(*go.uber.org/zap.Logger).DPanic",
+			"(*go.uber.org/zap.Logger).Panic",
+			"(*go.uber.org/zap.Logger).Fatal",
+			"(*go.uber.org/zap.Logger).Log",
 		}),
+		mustNewStaticRuleSet("slog", []string{
+			"log/slog.Debug",
+			"log/slog.Info",
+			"log/slog.Warn",
+			"log/slog.Error",
+			"log/slog.DebugContext",
+			"log/slog.InfoContext",
+			"log/slog.WarnContext",
+			"log/slog.ErrorContext",
+			"log/slog.Log",
+			"log/slog.LogAttrs",
+			"log/slog.With",
+			"log/slog.Group",
+			"(*log/slog.Logger).Debug",
+			"(*log/slog.Logger).Info",
+			"(*log/slog.Logger).Warn",
+			"(*log/slog.Logger).Error",
+			"(*log/slog.Logger).DebugContext",
+			"(*log/slog.Logger).InfoContext",
+			"(*log/slog.Logger).WarnContext",
+			"(*log/slog.Logger).ErrorContext",
+			"(*log/slog.Logger).Log",
+			"(*log/slog.Logger).LogAttrs",
+			"(*log/slog.Logger).With",
+		}),
+		mustNewStaticRuleSet("zerolog", []string{
+			"(*github.com/rs/zerolog.Logger).Trace",
+			"(*github.com/rs/zerolog.Logger).Debug",
+			"(*github.com/rs/zerolog.Logger).Info",
+			"(*github.com/rs/zerolog.Logger).Warn",
+			"(*github.com/rs/zerolog.Logger).Error",
+			"(*github.com/rs/zerolog.Logger).Err",
+			"(*github.com/rs/zerolog.Logger).Fatal",
+			"(*github.com/rs/zerolog.Logger).Panic",
+			"(*github.com/rs/zerolog.Logger).WithLevel",
+			"(*github.com/rs/zerolog.Logger).Log",
+			"(github.com/rs/zerolog.Logger).With",
+			"(*github.com/rs/zerolog.Event).Msg",
+			"(*github.com/rs/zerolog.Event).Msgf",
+			"(*github.com/rs/zerolog.Event).MsgFunc",
+			"(*github.com/rs/zerolog.Event).Send",
+			"(github.com/rs/zerolog.Context).Logger",
+		}),
+		mustNewStaticRuleSet("zerolog", []string{
+			"github.com/rs/zerolog/log.Trace",
+			"github.com/rs/zerolog/log.Debug",
+			"github.com/rs/zerolog/log.Info",
+			"github.com/rs/zerolog/log.Warn",
+			"github.com/rs/zerolog/log.Error",
+			"github.com/rs/zerolog/log.Err",
+			"github.com/rs/zerolog/log.Fatal",
+			"github.com/rs/zerolog/log.Panic",
+			"github.com/rs/zerolog/log.WithLevel",
+			"github.com/rs/zerolog/log.Log",
+			"github.com/rs/zerolog/log.With",
+		}),
+		mustNewStaticRuleSet("logrus", logrusLogFuncs()),
 		mustNewStaticRuleSet("kitlog", []string{
 			"github.com/go-kit/log.With",
 			"github.com/go-kit/log.WithPrefix",
@@ -40,12 +107,176 @@ var (
 			"(github.com/go-kit/log.Logger).Log",
 		}),
 	}
+	// staticTraceRuleList holds the functions of each trace policy, by ruleset name.
+	staticTraceRuleList = []rules.Ruleset{
+		mustNewStaticRuleSet(checkers.TracePolicyConstructor, []string{
+			"github.com/go-logr/logr.New",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyConstructor, []string{
+			"github.com/go-logr/zapr.NewLogger",
+			"github.com/go-logr/zapr.NewLoggerWithOptions",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyConstructor, []string{
+			"github.com/go-logr/stdr.New",
+			"github.com/go-logr/stdr.NewWithOptions",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyConstructor, []string{
+			"k8s.io/klog/v2.NewKlogr",
+			"k8s.io/klog/v2.Background",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyConstructor, []string{
+			"go.uber.org/zap.New",
+			"go.uber.org/zap.NewProduction",
+			"go.uber.org/zap.NewDevelopment",
+			"go.uber.org/zap.NewExample",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyConstructor, []string{
+			"github.com/go-kit/log.NewLogfmtLogger",
+			"github.com/go-kit/log.NewJSONLogger",
+			"github.com/go-kit/log.NewNopLogger",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyConstructor, []string{
+			"log/slog.New",
+			"log/slog.Default",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyConstructor, []string{
+			"github.com/rs/zerolog.New",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyConstructor, []string{
+			"github.com/sirupsen/logrus.New",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyFromContext, []string{
+			"github.com/go-logr/logr.FromContext",
+			"github.com/go-logr/logr.FromContextOrDiscard",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyFromContext, []string{
+			"k8s.io/klog/v2.FromContext",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyFromContext, []string{
+			"sigs.k8s.io/controller-runtime/pkg/log.FromContext",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyFromContext, []string{
+			"github.com/rs/zerolog.Ctx",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyFromContext, []string{
+			"github.com/rs/zerolog/log.Ctx",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyWithValues, []string{
+			"(github.com/go-logr/logr.Logger).WithValues",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyWithValues, []string{
+			"k8s.io/klog/v2.LoggerWithValues",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyWithValues, []string{
+			"(*go.uber.org/zap.SugaredLogger).With",
+			"(*go.uber.org/zap.Logger).With",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyWithValues, []string{
+			"log/slog.With",
+			"(*log/slog.Logger).With",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyWithValues, []string{
+			"(github.com/rs/zerolog.Context).Logger",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyWithValues, []string{
+			"github.com/go-kit/log.With",
+			"github.com/go-kit/log.WithPrefix",
+			"github.com/go-kit/log.WithSuffix",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyLogCall, []string{
+			"(github.com/go-logr/logr.Logger).Error",
+			"(github.com/go-logr/logr.Logger).Info",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyLogCall, []string{
+			"k8s.io/klog/v2.InfoS",
+			"k8s.io/klog/v2.InfoSDepth",
+			"k8s.io/klog/v2.ErrorS",
+			"(k8s.io/klog/v2.Verbose).InfoS",
+			"(k8s.io/klog/v2.Verbose).InfoSDepth",
+			"(k8s.io/klog/v2.Verbose).ErrorS",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyLogCall, []string{
+			"(*go.uber.org/zap.SugaredLogger).Debugw",
+			"(*go.uber.org/zap.SugaredLogger).Infow",
+			"(*go.uber.org/zap.SugaredLogger).Warnw",
+			"(*go.uber.org/zap.SugaredLogger).Errorw",
+			"(*go.uber.org/zap.SugaredLogger).DPanicw",
+			"(*go.uber.org/zap.SugaredLogger).Panicw",
+			"(*go.uber.org/zap.SugaredLogger).Fatalw",
+			"(*go.uber.org/zap.Logger).Debug",
+			"(*go.uber.org/zap.Logger).Info",
+			"(*go.uber.org/zap.Logger).Warn",
+			"(*go.uber.org/zap.Logger).Error",
+			"(*go.uber.org/zap.Logger).DPanic",
+			"(*go.uber.org/zap.Logger).Panic",
+			"(*go.uber.org/zap.Logger).Fatal",
+			"(*go.uber.org/zap.Logger).Log",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyLogCall, []string{
+			"log/slog.Debug",
+			"log/slog.Info",
+			"log/slog.Warn",
+			"log/slog.Error",
+			"log/slog.DebugContext",
+			"log/slog.InfoContext",
+			"log/slog.WarnContext",
+			"log/slog.ErrorContext",
+			"log/slog.Log",
+			"log/slog.LogAttrs",
+			"(*log/slog.Logger).Debug",
+			"(*log/slog.Logger).Info",
+			"(*log/slog.Logger).Warn",
+			"(*log/slog.Logger).Error",
+			"(*log/slog.Logger).DebugContext",
+			"(*log/slog.Logger).InfoContext",
+			"(*log/slog.Logger).WarnContext",
+			"(*log/slog.Logger).ErrorContext",
+			"(*log/slog.Logger).Log",
+			"(*log/slog.Logger).LogAttrs",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyLogCall, []string{
+			"(*github.com/rs/zerolog.Event).Msg",
+			"(*github.com/rs/zerolog.Event).Msgf",
+			"(*github.com/rs/zerolog.Event).MsgFunc",
+			"(*github.com/rs/zerolog.Event).Send",
+		}),
+		mustNewStaticRuleSet(checkers.TracePolicyLogCall, logrusLogFuncs()),
+		mustNewStaticRuleSet(checkers.TracePolicyLogCall, []string{
+			"(github.com/go-kit/log.Logger).Log",
+		}),
+	}
 	checkerByRulesetName = map[string]checkers.Checker{
 		// by default, checkers.General will be used.
-		"zap": checkers.Zap{},
+		"zap":     checkers.Zap{},
+		"slog":    checkers.Slog{},
+		"zerolog": checkers.Zerolog{},
+		"logrus":  checkers.Logrus{},
+		"kitlog":  checkers.Kitlog{},
 	}
 )
 
+// logrusLogFuncs returns the logging functions of logrus, in each level and
+// formatting variant, for the standard logger, a Logger and an Entry.
+func logrusLogFuncs() []string {
+	var funcs []string
+	for _, recv := range []string{"", "(*github.com/sirupsen/logrus.Logger)", "(*github.com/sirupsen/logrus.Entry)"} {
+		prefix := "github.com/sirupsen/logrus"
+		if recv != "" {
+			prefix = recv
+		}
+		levels := []string{"Trace", "Debug", "Info", "Print", "Warn", "Warning", "Error", "Fatal", "Panic"}
+		if recv != "" {
+			levels = append(levels, "Log")
+		}
+		for _, level := range levels {
+			for _, variant := range []string{"", "f", "ln"} {
+				funcs = append(funcs, prefix+"."+level+variant)
+			}
+		}
+	}
+	return funcs
+}
+
 // mustNewStaticRuleSet only called at init, catch errors during development.
 // In production it will not panic.
 func mustNewStaticRuleSet(name string, lines []string) rules.Ruleset {
@@ -0,0 +1,53 @@
+package contextlogging
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+func ContextVariant(ctx context.Context, logger *slog.Logger) {
+	logger.InfoContext(ctx, "message")
+	slog.ErrorContext(ctx, "message", "key", "value")
+	logger.Log(ctx, slog.LevelInfo, "message")
+	slog.LogAttrs(ctx, slog.LevelInfo, "message", slog.Int("count", 1))
+
+	logger.Info("message")                  // want `Info does not log the span of the context, use InfoContext`
+	slog.Warn("message", "key", "value")    // want `Warn does not log the span of the context, use WarnContext`
+	logger.Debug("message", "key", "value") // want `Debug does not log the span of the context, use DebugContext`
+}
+
+func DerivedContext(ctx context.Context, logger *slog.Logger) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	logger.InfoContext(ctx, "message")
+
+	child := context.WithValue(ctx, "key", "value")
+	logger.InfoContext(child, "message")
+
+	func() {
+		logger.InfoContext(ctx, "message")
+	}()
+}
+
+func OtherContext(ctx context.Context, logger *slog.Logger) {
+	logger.InfoContext(context.Background(), "message")                                        // want `InfoContext does not log the span of the function's context, pass ctx`
+	logger.InfoContext(context.Background(), "message", "traceId", "value", "spanId", "value") // want `InfoContext does not log the span of the function's context, pass ctx`
+}
+
+func Request(w http.ResponseWriter, r *http.Request) {
+	slog.InfoContext(r.Context(), "message")
+	slog.Info("message") // want `Info does not log the span of the context, use InfoContext`
+}
+
+func NoContextVariant(ctx context.Context, logger logr.Logger) {
+	logger.Info("message") // want `missing traceId in logging keys`
+	logger.Info("message", "traceId", "value", "spanId", "value")
+}
+
+func NoContext(logger *slog.Logger) {
+	logger.Info("message")
+}
@@ -0,0 +1,15 @@
+package contextlogging
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+func Logrus(ctx context.Context, logger *logrus.Logger) {
+	logger.WithContext(ctx).Info("message")
+	logrus.WithContext(ctx).WithField("key", "value").Info("message")
+	logger.WithField("key", "value").Info("message")   // want `Info does not log the span of the context, add .WithContext\(ctx\)`
+	logrus.Info("message")                             // want `Info does not log the span of the context, add .WithContext\(ctx\)`
+	logrus.WithContext(context.TODO()).Info("message") // want `Info does not log the span of the function's context, pass ctx`
+}
@@ -0,0 +1,13 @@
+package contextlogging
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+func Zerolog(ctx context.Context) {
+	log.Info().Ctx(ctx).Str("key", "value").Msg("message")
+	log.Info().Str("key", "value").Msg("message")       // want `Msg does not log the span of the context, add .Ctx\(ctx\)`
+	log.Info().Ctx(context.Background()).Msg("message") // want `Msg does not log the span of the function's context, pass ctx`
+}
@@ -0,0 +1,12 @@
+package contextparam
+
+import (
+	. "context"
+
+	"github.com/go-logr/zapr"
+	"go.uber.org/zap"
+)
+
+func ExampleDotImport(ctx Context) {
+	zapr.NewLogger(zap.L()).WithValues("key", "value") // want `missing traceId in logging keys`
+}
@@ -0,0 +1,43 @@
+package contextparam
+
+import (
+	stdctx "context"
+
+	"github.com/go-logr/zapr"
+	"go.uber.org/zap"
+)
+
+type Ctx = stdctx.Context
+
+type RequestContext interface {
+	stdctx.Context
+	RequestID() string
+}
+
+type handlerContext struct {
+	stdctx.Context
+}
+
+func ExampleAliasedImport(c stdctx.Context) {
+	zapr.NewLogger(zap.L()).WithValues("key", "value") // want `missing traceId in logging keys`
+}
+
+func ExampleTypeAlias(reqCtx Ctx) {
+	zapr.NewLogger(zap.L()).WithValues("key", "value") // want `missing traceId in logging keys`
+}
+
+func ExampleEmbeddingInterface(ctx RequestContext) {
+	zapr.NewLogger(zap.L()).WithValues("key", "value") // want `missing traceId in logging keys`
+}
+
+func ExampleImplementingStruct(ctx handlerContext) {
+	zapr.NewLogger(zap.L()).WithValues("key", "value") // want `missing traceId in logging keys`
+}
+
+func ExampleUnnamed(string, stdctx.Context) {
+	zapr.NewLogger(zap.L()).WithValues("key", "value") // want `missing traceId in logging keys`
+}
+
+func ExampleNoContext(name string, done <-chan struct{}) {
+	zapr.NewLogger(zap.L()).WithValues("key", "value")
+}
@@ -0,0 +1,36 @@
+package contextsource
+
+import (
+	"net/http"
+
+	"github.com/go-logr/zapr"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"a/contextsource/web"
+)
+
+func ExampleHandler(w http.ResponseWriter, r *http.Request) {
+	zapr.NewLogger(zap.L()).WithValues("key", "value") // want `missing traceId in logging keys`
+
+	span := trace.SpanFromContext(r.Context())
+	zapr.NewLogger(zap.L()).WithValues("traceId", span.SpanContext().TraceID().String(), "spanId", "value")
+}
+
+func ExampleFrameworkContext(c *web.Context) {
+	zapr.NewLogger(zap.L()).WithValues("key", "value") // want `missing traceId in logging keys`
+
+	span := trace.SpanFromContext(c.Request.Context())
+	zapr.NewLogger(zap.L()).WithValues("traceId", span.SpanContext().TraceID().String(), "spanId", "value")
+}
+
+func ExampleStream(stream web.Greeter_HelloServer) {
+	zapr.NewLogger(zap.L()).WithValues("key", "value") // want `missing traceId in logging keys`
+
+	span := trace.SpanFromContext(stream.Context())
+	zapr.NewLogger(zap.L()).WithValues("traceId", span.SpanContext().TraceID().String(), "spanId", "value")
+}
+
+func ExampleNoSource(w http.ResponseWriter) {
+	zapr.NewLogger(zap.L()).WithValues("key", "value")
+}
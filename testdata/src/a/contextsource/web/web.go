@@ -0,0 +1,22 @@
+package web
+
+import (
+	"context"
+	"net/http"
+)
+
+// Context mimics the context of web frameworks, which holds the request.
+type Context struct {
+	Request *http.Request
+}
+
+// ServerStream mimics a gRPC server stream.
+type ServerStream interface {
+	Context() context.Context
+}
+
+// Greeter_HelloServer mimics a generated gRPC stream.
+type Greeter_HelloServer interface {
+	Send(msg string) error
+	ServerStream
+}
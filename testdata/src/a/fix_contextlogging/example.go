@@ -0,0 +1,29 @@
+package fix_contextlogging
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+)
+
+func Logger(ctx context.Context, logger *slog.Logger) {
+	logger.Info("message", "key", "value") // want `Info does not log the span of the context, use InfoContext`
+}
+
+func Package(context.Context, string) {
+	slog.Error("message") // want `Error does not log the span of the context, use ErrorContext`
+}
+
+func Request(w http.ResponseWriter, r *http.Request) {
+	slog.Warn("message") // want `Warn does not log the span of the context, use WarnContext`
+}
+
+func OtherContext(ctx context.Context, logger *slog.Logger) {
+	logger.InfoContext(context.Background(), "message") // want `InfoContext does not log the span of the function's context, pass ctx`
+}
+
+func Shadowed(ctx context.Context) {
+	for ctx := range []int{1} {
+		slog.Info("message", "i", ctx) // want `Info does not log the span of the context, use InfoContext, cannot suggest a fix: context parameter ctx is shadowed at the logging call`
+	}
+}
@@ -0,0 +1,12 @@
+package fix_contextlogging
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+func Zerolog(ctx context.Context) {
+	log.Info().Str("key", "value").Msg("message")                     // want `Msg does not log the span of the context, add .Ctx\(ctx\)`
+	log.Info().Ctx(context.TODO()).Str("key", "value").Msg("message") // want `Msg does not log the span of the function's context, pass ctx`
+}
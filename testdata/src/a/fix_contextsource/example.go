@@ -0,0 +1,16 @@
+package fix_contextsource
+
+import (
+	"net/http"
+
+	"github.com/go-logr/zapr"
+	"go.uber.org/zap"
+)
+
+func Handler(w http.ResponseWriter, req *http.Request) {
+	zapr.NewLogger(zap.L()).WithValues("path", req.URL.Path) // want `missing traceId in logging keys`
+}
+
+func UnnamedHandler(http.ResponseWriter, *http.Request) {
+	zapr.NewLogger(zap.L()).WithValues("path", "unknown") // want `missing traceId in logging keys`
+}
@@ -0,0 +1,23 @@
+package datadog
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+
+	"a/fixtemplate/tracer"
+)
+
+func Log(ctx context.Context, log logr.Logger, id string) {
+	log.Info("message", "id", id) // want `missing traceId in logging keys`
+}
+
+func Reuse(ctx context.Context, log logr.Logger) {
+	span, _ := tracer.SpanFromContext(ctx)
+	log.Info("message", "dd.trace_id", span.Context().TraceID()) // want `missing spanId in logging keys`
+}
+
+func Accepted(ctx context.Context, log logr.Logger) {
+	span, _ := tracer.SpanFromContext(ctx)
+	log.Info("message", "dd.trace_id", span.Context().TraceID(), "dd.span_id", span.Context().SpanID())
+}
@@ -0,0 +1,19 @@
+package helper
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+)
+
+func Log(ctx context.Context, log logr.Logger, id string) {
+	log.Info("message", "id", id) // want `missing traceId in logging keys`
+}
+
+func SpanOnly(ctx context.Context, log logr.Logger, traceID string) {
+	log.Info("message", "trace_id", traceID) // want `missing spanId in logging keys`
+}
+
+func Unnamed(_ context.Context, log logr.Logger) {
+	log.Info("message") // want `missing traceId in logging keys`
+}
@@ -0,0 +1,24 @@
+package opencensus
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+
+	"a/fixtemplate/octrace"
+)
+
+func Log(ctx context.Context, log logr.Logger, id string) {
+	log.Info("message", "id", id) // want `missing traceId in logging keys`
+}
+
+func Reuse(ctx context.Context, log logr.Logger) {
+	ctx, span := trace.StartSpan(ctx, "op")
+	defer span.End()
+	log.Info("message") // want `missing traceId in logging keys`
+}
+
+func Flags(ctx context.Context, log logr.Logger) {
+	span := trace.FromContext(ctx)
+	log.Info("message", "traceId", span.SpanContext().TraceID.String(), "spanId", span.SpanContext().SpanID.String()) // want `missing trace flags in logging keys`
+}
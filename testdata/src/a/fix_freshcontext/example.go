@@ -0,0 +1,56 @@
+package fix_freshcontext
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func fetch(ctx context.Context, id string) error {
+	return nil
+}
+
+func Handle(ctx context.Context, id string) error {
+	return fetch(context.Background(), id) // want `context.Background\(\) passed to fetch drops the span of the function's context, use ctx`
+}
+
+func Assigned(ctx context.Context, id string) error {
+	bg := context.TODO() // want `context.TODO\(\) drops the span of the function's context, use ctx`
+	return fetch(bg, id)
+}
+
+func Closure(ctx context.Context, id string) {
+	func() {
+		_ = fetch(context.Background(), id) // want `context.Background\(\) passed to fetch drops the span of the function's context, use ctx`
+	}()
+}
+
+func Request(w http.ResponseWriter, r *http.Request) {
+	_ = fetch(context.Background(), r.URL.Path) // want `context.Background\(\) passed to fetch drops the span of the function's context, use r.Context\(\)`
+}
+
+func Unnamed(context.Context, string) {
+	_ = fetch(context.Background(), "id") // want `context.Background\(\) passed to fetch drops the span of the function's context, use ctx`
+}
+
+func Shadowed(ctx context.Context) {
+	{
+		ctx := 1
+		_ = ctx
+		_ = fetch(context.Background(), "id") // want `context.Background\(\) passed to fetch drops the span of the function's context, cannot suggest a fix: context parameter ctx is shadowed at the logging call`
+	}
+}
+
+func Detached(ctx context.Context) {
+	go func() {
+		_ = fetch(trace.ContextWithSpan(context.Background(), trace.SpanFromContext(ctx)), "id")
+	}()
+	go func() {
+		_ = fetch(context.WithoutCancel(ctx), "id")
+	}()
+}
+
+func NoContext(id string) error {
+	return fetch(context.Background(), id)
+}
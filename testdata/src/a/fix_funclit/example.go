@@ -0,0 +1,40 @@
+package fix_funclit
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-logr/zapr"
+	"go.uber.org/zap"
+)
+
+var Handle = func(ctx context.Context, name string) {
+	zapr.NewLogger(zap.L()).WithValues("name", name) // want `missing traceId in logging keys`
+}
+
+func Goroutine(ctx context.Context, names []string) {
+	for _, name := range names {
+		go func(name string) {
+			zapr.NewLogger(zap.L()).WithValues("name", name) // want `missing traceId in logging keys`
+		}(name)
+	}
+}
+
+func Worker() func(context.Context) {
+	return func(_ context.Context) {
+		zapr.NewLogger(zap.L()).WithValues("worker", "started") // want `missing traceId in logging keys`
+	}
+}
+
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		zapr.NewLogger(zap.L()).WithValues("path", r.URL.Path) // want `missing traceId in logging keys`
+		next.ServeHTTP(w, r)
+	})
+}
+
+func Shadowed(ctx context.Context) {
+	func(ctx string) {
+		zapr.NewLogger(zap.L()).WithValues("name", ctx) // want `missing traceId in logging keys, cannot suggest a fix: context parameter ctx is shadowed where the span is declared`
+	}("shadowed")
+}
@@ -19,9 +19,9 @@ func SomeFunc(ctx context.Context, eventType, deliveryID string, payload []byte)
 
 func SomeFunc1(ctx context.Context, eventType, deliveryID string, payload []byte) error {
 	telemetryInstance := telemetry{
-		TraceLogKey: "dummyTrace",
+		TraceLogKey: "traceId",
 	}
-	log := zapr.NewLogger(zap.L()).WithValues(telemetryInstance.TraceLogKey, "someValue") // cannot be detected
+	log := zapr.NewLogger(zap.L()).WithValues(telemetryInstance.TraceLogKey, "someValue") // want `missing spanId in logging keys`
 	log = log.WithValues("eventType", "hello")
 	log.Info("Tracing")
 	return nil
@@ -32,4 +32,53 @@ func SomeFunc2(eventType, deliveryID string, payload []byte) error {
 	log = log.WithValues("eventType", "hello")
 	log.Info("Tracing")
 	return nil
-}
\ No newline at end of file
+}
+
+func SomeFunc3(ctx context.Context, eventType string) error {
+	const traceKey = "request"
+	log := zapr.NewLogger(zap.L()).WithValues(traceKey, eventType) // want `missing traceId in logging keys`
+	log.Info("Tracing")
+	return nil
+}
+
+func SomeFunc4(ctx context.Context, spanID string) error {
+	log := zapr.NewLogger(zap.L()).WithValues("span_id", spanID) // want `missing traceId in logging keys`
+	log.Info("Tracing")
+	return nil
+}
+
+func SomeFunc5(c context.Context, eventType string) error {
+	log := zapr.NewLogger(zap.L()).WithValues("eventType", eventType) // want `missing traceId in logging keys`
+	log.Info("Tracing")
+	return nil
+}
+
+func SomeFunc6(eventType string, reqCtx context.Context) error {
+	log := zapr.NewLogger(zap.L()).WithValues("eventType", eventType) // want `missing traceId in logging keys`
+	log.Info("Tracing")
+	return nil
+}
+
+func SomeFunc7(_ context.Context, eventType string) error {
+	log := zapr.NewLogger(zap.L()).WithValues("eventType", eventType) // want `missing traceId in logging keys`
+	log.Info("Tracing")
+	return nil
+}
+
+func SomeFunc8(string, context.Context) error {
+	log := zapr.NewLogger(zap.L()).WithValues("eventType", "unnamed") // want `missing traceId in logging keys`
+	log.Info("Tracing")
+	return nil
+}
+
+func SomeFunc9(span context.Context, eventType string) error {
+	log := zapr.NewLogger(zap.L()).WithValues("eventType", eventType) // want `missing traceId in logging keys`
+	log.Info("Tracing")
+	return nil
+}
+
+func SomeFunc10(_ context.Context, ctx string) error {
+	log := zapr.NewLogger(zap.L()).WithValues("eventType", ctx) // want `missing traceId in logging keys, cannot suggest a fix: context parameter cannot be named ctx, the name is already in use`
+	log.Info("Tracing")
+	return nil
+}
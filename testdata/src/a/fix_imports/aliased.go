@@ -0,0 +1,13 @@
+package fix_imports
+
+import (
+	"context"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+var _ oteltrace.Span
+
+func Aliased(ctx context.Context, log Logger) {
+	log.Info("message") // want `missing traceId in logging keys`
+}
@@ -0,0 +1,15 @@
+package fix_imports
+
+import (
+	"context"
+	"runtime/trace"
+)
+
+func Collision(ctx context.Context, log Logger) {
+	defer trace.StartRegion(ctx, "collision").End()
+	log.Info("message") // want `missing traceId in logging keys`
+}
+
+func Param(trace string, ctx context.Context, log Logger) {
+	log.Info(trace) // want `missing traceId in logging keys`
+}
@@ -0,0 +1,5 @@
+package fix_imports
+
+func NoImports(ctx Context, log Logger) {
+	log.Info("message") // want `missing traceId in logging keys`
+}
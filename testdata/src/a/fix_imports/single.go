@@ -0,0 +1,8 @@
+package fix_imports
+
+import "context"
+import "github.com/go-logr/logr"
+
+func Single(ctx context.Context, log logr.Logger) {
+	log.Info("message") // want `missing traceId in logging keys`
+}
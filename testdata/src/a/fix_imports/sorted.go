@@ -0,0 +1,14 @@
+package fix_imports
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"go.uber.org/zap" // the global logger
+)
+
+var _ = zap.NewNop
+
+func Sorted(ctx context.Context, log logr.Logger) {
+	log.Info("message") // want `missing traceId in logging keys`
+}
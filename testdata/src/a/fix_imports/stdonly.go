@@ -0,0 +1,10 @@
+package fix_imports
+
+import (
+	"context"
+	"fmt"
+)
+
+func StdOnly(ctx context.Context, log Logger, id int) {
+	log.Info(fmt.Sprint(id)) // want `missing traceId in logging keys`
+}
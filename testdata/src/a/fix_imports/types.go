@@ -0,0 +1,12 @@
+package fix_imports
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+)
+
+type (
+	Context = context.Context
+	Logger  = logr.Logger
+)
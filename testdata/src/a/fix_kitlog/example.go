@@ -0,0 +1,19 @@
+package fix_kitlog
+
+import (
+	"context"
+
+	"github.com/go-kit/log"
+)
+
+func Log(ctx context.Context, logger log.Logger, id string) {
+	logger.Log("msg", "message", "id", id) // want `missing traceId in logging keys`
+}
+
+func SpanKey(ctx context.Context, logger log.Logger, traceID string) {
+	logger.Log("msg", "message", "traceId", traceID) // want `missing spanId in logging keys`
+}
+
+func With(ctx context.Context, logger log.Logger, id string) log.Logger {
+	return log.With(logger, "id", id) // want `missing traceId in logging keys`
+}
@@ -0,0 +1,19 @@
+package fix_klog
+
+import (
+	"context"
+
+	"k8s.io/klog/v2"
+)
+
+func InfoS(ctx context.Context, id string) {
+	klog.InfoS("message", "id", id) // want `missing traceId in logging keys`
+}
+
+func ErrorS(ctx context.Context, err error, id string) {
+	klog.ErrorS(err, "message", "id", id) // want `missing traceId in logging keys`
+}
+
+func FromContext(ctx context.Context, id string) {
+	klog.FromContext(ctx).Info("message", "id", id) // want `missing traceId in logging keys`
+}
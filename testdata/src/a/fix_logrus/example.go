@@ -0,0 +1,26 @@
+package fix_logrus
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+func Std(ctx context.Context) {
+	logrus.Info("message") // want `missing traceId in logging keys`
+}
+
+func Entry(ctx context.Context, logger *logrus.Logger) {
+	logger.WithField("id", "value").Info("message") // want `missing traceId in logging keys`
+}
+
+func Fields(ctx context.Context, logger *logrus.Logger) {
+	logger.WithFields(logrus.Fields{ // want `missing traceId in logging keys`
+		"id": "value",
+	}).Info("message")
+}
+
+func Span(ctx context.Context, logger *logrus.Logger, traceID string) {
+	logger.WithFields(logrus.Fields{"traceId": traceID}).Info("message") // want `missing spanId in logging keys`
+	logger.WithField("traceId", traceID).Info("message")                 // want `missing spanId in logging keys`
+}
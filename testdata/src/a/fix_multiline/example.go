@@ -0,0 +1,31 @@
+package fix_multiline
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+)
+
+func MultiLine(ctx context.Context, log logr.Logger, id, name string) {
+	_ = log.WithValues( // want `missing traceId in logging keys`
+		"id", id, // the request id
+		// the user name
+		"name", name,
+	)
+}
+
+func NoValues(ctx context.Context, log logr.Logger) {
+	_ = log.WithValues() // want `missing traceId in logging keys`
+}
+
+func TrailingComma(ctx context.Context, log logr.Logger, id string) {
+	_ = log.WithValues( // want `missing traceId in logging keys`
+		"id", id,
+	)
+}
+
+func Nested(ctx context.Context, log logr.Logger, ok bool) {
+	if ok {
+		_ = log.WithValues("ok", ok) // want `missing traceId in logging keys`
+	}
+}
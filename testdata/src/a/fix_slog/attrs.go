@@ -0,0 +1,13 @@
+package fix_slog
+
+import (
+	"context"
+)
+
+func AttrsWithoutImport(ctx context.Context, id string) {
+	logger().LogAttrs(ctx, level, "message") // want `missing traceId in logging keys`
+}
+
+func KeyValuesWithoutImport(ctx context.Context, id string) {
+	logger().Info("message", "id", id) // want `missing traceId in logging keys`
+}
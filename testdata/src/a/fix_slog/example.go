@@ -0,0 +1,18 @@
+package fix_slog
+
+import (
+	"context"
+	"log/slog"
+)
+
+func KeyValues(ctx context.Context, logger *slog.Logger, id string) {
+	logger.Info("message", "id", id) // want `missing traceId in logging keys`
+}
+
+func Attrs(ctx context.Context, id string) {
+	slog.LogAttrs(ctx, slog.LevelInfo, "message", slog.String("id", id)) // want `missing traceId in logging keys`
+}
+
+func SpanAttr(ctx context.Context, traceID string) {
+	slog.Info("message", slog.String("traceId", traceID)) // want `missing spanId in logging keys`
+}
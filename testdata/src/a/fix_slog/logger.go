@@ -0,0 +1,9 @@
+package fix_slog
+
+import "log/slog"
+
+var level = slog.LevelInfo
+
+func logger() *slog.Logger {
+	return slog.Default()
+}
@@ -0,0 +1,43 @@
+package fix_spanpos
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func Started(ctx context.Context, tracer trace.Tracer, log logr.Logger) {
+	log.Info("before") // want `missing traceId in logging keys`
+	ctx, _ = tracer.Start(ctx, "op")
+	log.Info("after") // want `missing traceId in logging keys`
+}
+
+func Branch(ctx context.Context, tracer trace.Tracer, log logr.Logger, traced bool) {
+	if traced {
+		ctx, _ = tracer.Start(ctx, "op")
+	}
+	log.Info("after") // want `missing traceId in logging keys`
+}
+
+func Block(ctx context.Context, tracer trace.Tracer, log logr.Logger, traced bool) {
+	if traced {
+		ctx, _ := tracer.Start(ctx, "op")
+		log.Info("started") // want `missing traceId in logging keys`
+		_ = ctx
+	}
+}
+
+func Restarted(ctx context.Context, tracer trace.Tracer, log logr.Logger) {
+	ctx, span := tracer.Start(ctx, "first")
+	defer span.End()
+	ctx, _ = tracer.Start(ctx, "second")
+	log.Info("second") // want `missing traceId in logging keys`
+}
+
+func AssignedLater(ctx context.Context, tracer trace.Tracer, log logr.Logger) {
+	log.Info("first")  // want `missing traceId in logging keys`
+	log.Info("second") // want `missing traceId in logging keys`
+	ctx, _ = tracer.Start(ctx, "op")
+	_ = ctx
+}
@@ -0,0 +1,53 @@
+package fix_spanreuse
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func Started(ctx context.Context, tracer trace.Tracer, log logr.Logger) {
+	ctx, span := tracer.Start(ctx, "op")
+	defer span.End()
+	log.Info("started") // want `missing traceId in logging keys`
+}
+
+func Twice(ctx context.Context, log logr.Logger) {
+	log.Info("first")  // want `missing traceId in logging keys`
+	log.Info("second") // want `missing traceId in logging keys`
+}
+
+func Closure(ctx context.Context, tracer trace.Tracer, log logr.Logger) {
+	ctx, opSpan := tracer.Start(ctx, "op")
+	defer opSpan.End()
+	func() {
+		log.Info("closure") // want `missing traceId in logging keys`
+	}()
+}
+
+func NameTaken(ctx context.Context, log logr.Logger, span int) {
+	log.Info("taken", "span", span) // want `missing traceId in logging keys`
+}
+
+func StartedLater(ctx context.Context, tracer trace.Tracer, log logr.Logger) {
+	log.Info("before") // want `missing traceId in logging keys`
+	_, span := tracer.Start(ctx, "op")
+	defer span.End()
+}
+
+func OwnContext(ctx context.Context, tracer trace.Tracer, log logr.Logger) {
+	ctx, span := tracer.Start(ctx, "op")
+	defer span.End()
+	go func(ctx context.Context) {
+		log.Info("inner") // want `missing traceId in logging keys`
+	}(context.WithoutCancel(ctx))
+}
+
+func LatestSpan(ctx context.Context, tracer trace.Tracer, log logr.Logger) {
+	_, a := tracer.Start(ctx, "early")
+	defer a.End()
+	ctx, z := tracer.Start(ctx, "op")
+	defer z.End()
+	log.Info("after") // want `missing traceId in logging keys`
+}
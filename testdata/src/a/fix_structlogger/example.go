@@ -0,0 +1,44 @@
+package fix_structlogger
+
+import (
+	"context"
+	"log"
+
+	"github.com/go-logr/logr"
+	"go.uber.org/zap"
+)
+
+type Server struct {
+	log logr.Logger
+	zap *zap.Logger
+}
+
+func (s *Server) Handle(ctx context.Context, id string) error {
+	s.log.Info("handling", "id", id) // want `missing traceId in logging keys of s.log, held by a struct`
+	if id == "" {
+		s.log.Error(nil, "no id") // want `missing traceId in logging keys of s.log, held by a struct`
+	}
+	return nil
+}
+
+func (s *Server) Zap(ctx context.Context, id string) {
+	s.zap.Info("handling", zap.String("id", id)) // want `missing traceId in logging keys of s.zap, held by a struct`
+}
+
+func (s *Server) Closure(ctx context.Context) {
+	done := func() {
+		s.log.Info("done") // want `missing traceId in logging keys of s.log, held by a struct`
+	}
+	s.log.Info("started") // want `missing traceId in logging keys of s.log, held by a struct`
+	done()
+}
+
+func (s *Server) NameInUse(ctx context.Context) {
+	log.Println("started")
+	s.log.Info("started") // want `missing traceId in logging keys of s.log, held by a struct`
+}
+
+func (s *Server) Assigned(ctx context.Context, base logr.Logger) {
+	s.log.Info("started") // want `missing traceId in logging keys of s.log, held by a struct, cannot suggest a fix: s.log is assigned in the function`
+	s.log = base
+}
@@ -0,0 +1,16 @@
+package fix_traceflags
+
+import (
+	"context"
+
+	"github.com/go-logr/zapr"
+	"go.uber.org/zap"
+)
+
+func MissingAll(ctx context.Context, eventType string) {
+	zapr.NewLogger(zap.L()).WithValues("eventType", eventType) // want `missing traceId in logging keys`
+}
+
+func MissingSpanAndFlags(ctx context.Context, traceID string) {
+	zapr.NewLogger(zap.L()).WithValues("traceId", traceID) // want `missing spanId in logging keys` `missing trace flags in logging keys`
+}
@@ -0,0 +1,19 @@
+package fix_zap
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+func Fields(ctx context.Context, logger *zap.Logger, id string) {
+	logger.Info("message", zap.String("id", id)) // want `missing traceId in logging keys`
+}
+
+func SpanField(ctx context.Context, logger *zap.Logger, traceID string) {
+	logger.Info("message", zap.String("traceId", traceID)) // want `missing spanId in logging keys`
+}
+
+func Sugar(ctx context.Context, logger *zap.SugaredLogger, id string) {
+	logger.Infow("message", "id", id) // want `missing traceId in logging keys`
+}
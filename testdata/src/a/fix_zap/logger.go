@@ -0,0 +1,7 @@
+package fix_zap
+
+import "go.uber.org/zap"
+
+func logger() *zap.Logger {
+	return zap.L()
+}
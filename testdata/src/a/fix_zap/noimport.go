@@ -0,0 +1,13 @@
+package fix_zap
+
+import (
+	"context"
+)
+
+func FieldsWithoutImport(ctx context.Context) {
+	logger().Info("message") // want `missing traceId in logging keys`
+}
+
+func SugarWithoutImport(ctx context.Context, id string) {
+	logger().Sugar().Infow("message", "id", id) // want `missing traceId in logging keys`
+}
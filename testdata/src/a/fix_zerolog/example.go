@@ -0,0 +1,24 @@
+package fix_zerolog
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+func Event(ctx context.Context, id string) {
+	log.Info().Str("id", id).Msg("message") // want `missing traceId in logging keys`
+}
+
+func Span(ctx context.Context, traceID string) {
+	log.Info().Str("traceId", traceID).Msg("message") // want `missing spanId in logging keys`
+}
+
+func Context(ctx context.Context, logger zerolog.Logger) zerolog.Logger {
+	return logger.With().Str("id", "value").Logger() // want `missing traceId in logging keys`
+}
+
+func NotSent(ctx context.Context) {
+	log.Info().Str("traceId", "value").Str("spanId", "value") // want `logging event is never sent`
+}
@@ -0,0 +1,30 @@
+// Package trace stands for the OpenCensus trace package.
+package trace
+
+import "context"
+
+type Span struct{}
+
+func (s *Span) End() {}
+
+func (s *Span) SpanContext() SpanContext { return SpanContext{} }
+
+type SpanContext struct {
+	TraceID      TraceID
+	SpanID       SpanID
+	TraceOptions TraceOptions
+}
+
+type TraceID [16]byte
+
+func (t TraceID) String() string { return "" }
+
+type SpanID [8]byte
+
+func (s SpanID) String() string { return "" }
+
+type TraceOptions uint32
+
+func FromContext(ctx context.Context) *Span { return nil }
+
+func StartSpan(ctx context.Context, name string) (context.Context, *Span) { return ctx, nil }
@@ -0,0 +1,9 @@
+// Package tracelog stands for in-house helpers returning the trace ids of
+// a context.
+package tracelog
+
+import "context"
+
+func TraceID(ctx context.Context) string    { return "" }
+func SpanID(ctx context.Context) string     { return "" }
+func TraceFlags(ctx context.Context) string { return "" }
@@ -0,0 +1,15 @@
+// Package tracer stands for the dd-trace-go tracer package.
+package tracer
+
+import "context"
+
+type Span interface {
+	Context() SpanContext
+}
+
+type SpanContext interface {
+	SpanID() uint64
+	TraceID() uint64
+}
+
+func SpanFromContext(ctx context.Context) (Span, bool) { return nil, false }
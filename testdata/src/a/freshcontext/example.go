@@ -0,0 +1,32 @@
+package freshcontext
+
+import (
+	"context"
+)
+
+func fetch(ctx context.Context, id string) error {
+	return nil
+}
+
+// detach returns a context holding the values of ctx, without its deadline.
+func detach(ctx context.Context) context.Context {
+	return valueOnly{Context: context.Background(), values: ctx}
+}
+
+type valueOnly struct {
+	context.Context
+	values context.Context
+}
+
+func (v valueOnly) Value(key any) any {
+	return v.values.Value(key)
+}
+
+func Handle(ctx context.Context, id string) error {
+	go fetch(detach(ctx), id)
+	return fetch(context.Background(), id) // want `context.Background\(\) passed to fetch drops the span of the function's context, use ctx`
+}
+
+func WithoutCancel(ctx context.Context) {
+	_ = context.WithoutCancel(context.Background()) // want `context.Background\(\) passed to WithoutCancel drops the span of the function's context, use ctx`
+}
@@ -1,17 +1,24 @@
 module a
 
-go 1.18
+go 1.21
 
 require (
 	github.com/go-kit/log v0.2.1
 	github.com/go-logr/logr v1.2.4
+	github.com/go-logr/zapr v1.2.4
+	github.com/rs/zerolog v1.31.0
+	github.com/sirupsen/logrus v1.9.3
+	go.opentelemetry.io/otel/trace v1.16.0
 	go.uber.org/zap v1.24.0
 	k8s.io/klog/v2 v2.70.1
 )
 
 require (
 	github.com/go-logfmt/logfmt v0.5.1 // indirect
-	github.com/go-logr/zapr v1.2.4 // indirect
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.19 // indirect
+	go.opentelemetry.io/otel v1.16.0 // indirect
 	go.uber.org/atomic v1.7.0 // indirect
 	go.uber.org/multierr v1.6.0 // indirect
+	golang.org/x/sys v0.12.0 // indirect
 )
@@ -0,0 +1,26 @@
+package kitlog
+
+import (
+	"context"
+	"os"
+
+	"github.com/go-kit/log"
+)
+
+func ExampleWith(ctx context.Context) {
+	logger := log.NewLogfmtLogger(os.Stderr)
+	_ = log.With(logger, "key", "value") // want `missing traceId in logging keys`
+	_ = log.With(logger, "traceId", "value", "spanId", "value")
+	_ = log.With(log.NewNopLogger(), "traceId", "value")                     // want `missing spanId in logging keys`
+	_ = log.WithPrefix(logger, "traceId", "value", "spanId", "value", "key") // want `odd number of arguments passed as key-value pairs for logging`
+}
+
+func ExampleLog(ctx context.Context, logger log.Logger) {
+	logger.Log("msg", "message", "key", "value") // want `missing traceId in logging keys`
+	logger.Log("msg", "message", "traceId", "value", "spanId", "value")
+	logger.Log("msg", "message", "traceId", "value") // want `missing spanId in logging keys`
+}
+
+func ExampleNoContext(logger log.Logger) {
+	logger.Log("msg", "message")
+}
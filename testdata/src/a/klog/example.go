@@ -0,0 +1,33 @@
+package klog
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/klog/v2"
+)
+
+func ExampleStructured(ctx context.Context) {
+	err := fmt.Errorf("error")
+	klog.InfoS("message", "key", "value") // want `missing traceId in logging keys`
+	klog.InfoS("message", "traceId", "value", "spanId", "value")
+	klog.ErrorS(err, "message", "traceId", "value") // want `missing spanId in logging keys`
+	klog.V(2).InfoS("message", "key", "value")      // want `missing traceId in logging keys`
+}
+
+func ExampleFromContext(ctx context.Context) {
+	_ = klog.FromContext(ctx).WithValues("key", "value") // want `missing traceId in logging keys`
+	_ = klog.FromContext(ctx).WithValues("traceId", "value", "spanId", "value")
+
+	logger := klog.FromContext(ctx)
+	logger.Info("message", "key", "value") // want `missing traceId in logging keys`
+}
+
+func ExampleLoggerWithValues(ctx context.Context) {
+	_ = klog.LoggerWithValues(klog.Background(), "key", "value") // want `missing traceId in logging keys`
+	_ = klog.LoggerWithValues(klog.Background(), "traceId", "value", "spanId", "value")
+}
+
+func ExampleNoContext() {
+	klog.InfoS("message", "key", "value")
+}
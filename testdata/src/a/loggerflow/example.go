@@ -0,0 +1,70 @@
+package loggerflow
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/zapr"
+	"github.com/rs/zerolog"
+	"go.uber.org/zap"
+)
+
+func ExampleReassigned(ctx context.Context, eventType string) {
+	log := zapr.NewLogger(zap.L()).WithValues("eventType", eventType) // want `missing traceId in logging keys`
+	log = log.WithValues("eventType", "hello")                        // want `missing traceId in logging keys`
+	log.Info("Tracing")                                               // want `missing traceId in logging keys`
+
+	log = zapr.NewLogger(zap.L()).WithValues("traceId", "value", "spanId", "value")
+	log = log.WithValues("eventType", "hello")
+	log.Info("Tracing")
+}
+
+func ExampleBranch(ctx context.Context, base logr.Logger, debug bool) {
+	log := base.WithValues("traceId", "value", "spanId", "value")
+	if debug {
+		log = log.WithValues("debug", true)
+	}
+	log.Info("message")
+
+	if debug {
+		log = base
+	}
+	log.Info("message") // want `missing traceId in logging keys`
+}
+
+func ExampleLoop(ctx context.Context, base logr.Logger, keys []string) {
+	log := base.WithValues("traceId", "value", "spanId", "value")
+	for _, key := range keys {
+		log = log.WithValues(key, "value")
+	}
+	log.Info("message")
+}
+
+type server struct {
+	log logr.Logger
+}
+
+func ExampleStructField(ctx context.Context, base logr.Logger) {
+	s := &server{log: base.WithValues("traceId", "value", "spanId", "value")}
+	s.log.Info("message")
+
+	u := &server{log: base}
+	u.log.Info("message") // want `missing traceId in logging keys`
+}
+
+func ExampleClosure(ctx context.Context, base logr.Logger) {
+	log := base.WithValues("traceId", "value", "spanId", "value")
+	func() {
+		log.Info("message")
+	}()
+	log.Info("message")
+}
+
+func ExampleZerolog(ctx context.Context, base zerolog.Logger) {
+	logger := base.With().Str("traceId", "value").Str("spanId", "value").Logger()
+	logger.Info().Str("key", "value").Msg("message")
+	sub := logger.With().Str("key", "value").Logger()
+	sub.Info().Msg("message")
+
+	base.Info().Msg("message") // want `missing traceId in logging keys`
+}
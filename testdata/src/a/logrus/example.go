@@ -0,0 +1,39 @@
+package logrus
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+func ExampleFields(ctx context.Context) {
+	logger := logrus.New()
+	logger.Info("message") // want `missing traceId in logging keys`
+	logger.WithField("traceId", "value").WithField("spanId", "value").Info("message")
+	logger.WithFields(logrus.Fields{"traceId": "value", "spanId": "value"}).Warnf("message %d", 1)
+	logger.WithFields(logrus.Fields{"traceId": "value"}).Error("message")  // want `missing spanId in logging keys`
+	logger.WithError(ctx.Err()).WithField("key", "value").Debug("message") // want `missing traceId in logging keys`
+	logger.WithField("traceId", "value").WithFields(logrus.Fields{"spanId": "value"}).Log(logrus.InfoLevel, "message")
+
+	fields := logrus.Fields{"key": "value"}
+	logger.WithFields(fields).Info("message")
+
+	entry := logger.WithField("key", "value")
+	entry.Info("message")
+
+	// Only loggers returned by a constructor or taken from the context are checked.
+	logrus.Info("message")
+	logrus.WithField("key", "value").Info("message")
+}
+
+func ExampleKeys(ctx context.Context, key string) {
+	logger := logrus.New()
+	logger.WithFields(logrus.Fields{"traceId": "value", "spanId": "value", key: "value"}).Info("message") // want `logging keys are expected to be inlined constant strings, please replace "key" provided with string`
+	logger.WithField("traceId", "value").WithField("spanId", "value").Info("100%d done")                  // want `logging message should not use format specifier "%d"`
+	logger.WithField("traceId", "value").WithField("spanId", "value").Infof("%d done", 100)
+}
+
+func ExampleNoContext() {
+	logger := logrus.New()
+	logger.Info("message")
+}
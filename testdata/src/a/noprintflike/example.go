@@ -0,0 +1,20 @@
+package noprintflike
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"go.uber.org/zap"
+)
+
+func ExampleNoPrintfLike() {
+	err := fmt.Errorf("error")
+
+	log := logr.Discard()
+	log.Info("message %s", "key", "value")        // want `logging message should not use format specifier "%s"`
+	log.Error(err, "message: %v", "key", "value") // want `logging message should not use format specifier "%v"`
+	log.Info("done", "progress", "100%")
+	log.Info("message", "key") // want `odd number of arguments passed as key-value pairs for logging`
+
+	zap.S().Infow("message %d", "key", "value") // want `logging message should not use format specifier "%d"`
+}
@@ -0,0 +1,32 @@
+package slog
+
+import (
+	"context"
+	"log/slog"
+)
+
+func ExampleKeyValues(ctx context.Context, logger *slog.Logger) {
+	logger.Info("message", "key", "value") // want `missing traceId in logging keys`
+	logger.Info("message", "traceId", "value", "spanId", "value")
+	logger.InfoContext(ctx, "message", "traceId", "value", "spanId", "value")
+	logger.With("traceId", "value").Info("message", "spanId", "value")   // want `missing spanId in logging keys` `missing traceId in logging keys`
+	logger.Info("message", "traceId", "value", "spanId", "value", "key") // want `odd number of arguments passed as key-value pairs for logging`
+	logger.Info("message", "key", "value", slog.String("traceId", "value"), "spanId", "value")
+}
+
+func ExampleAttrs(ctx context.Context) {
+	slog.Info("message", slog.String("traceId", "value"), slog.String("spanId", "value"))
+	slog.Info("message", slog.String("traceId", "value")) // want `missing spanId in logging keys`
+	slog.Info("message", slog.Attr{Key: "traceId", Value: slog.StringValue("value")}, "spanId", "value")
+	slog.LogAttrs(ctx, slog.LevelInfo, "message", slog.String("traceId", "value"), slog.Int("spanId", 1))
+	slog.LogAttrs(ctx, slog.LevelInfo, "message", slog.Int("count", 1)) // want `missing traceId in logging keys`
+}
+
+func ExampleGroup(ctx context.Context) {
+	slog.Info("message", slog.Group("request", "traceId", "value", "spanId", "value"))        // want `missing traceId in logging keys`
+	slog.Info("message", slog.Group("request", "key"), "traceId", "value", "spanId", "value") // want `odd number of arguments passed as key-value pairs for logging`
+}
+
+func ExampleNoContext(logger *slog.Logger) {
+	logger.Info("message", "key", "value")
+}
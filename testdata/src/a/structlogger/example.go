@@ -0,0 +1,51 @@
+package structlogger
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/go-logr/logr"
+	"go.uber.org/zap"
+)
+
+type Server struct {
+	log   logr.Logger
+	zap   *zap.Logger
+	slog  *slog.Logger
+	deps  deps
+	debug bool
+}
+
+type deps struct {
+	log logr.Logger
+}
+
+func (s *Server) Handle(ctx context.Context, id string) {
+	s.log.Info("handling", "id", id)             // want `missing traceId in logging keys of s.log, held by a struct`
+	s.zap.Info("handling", zap.String("id", id)) // want `missing traceId in logging keys of s.zap, held by a struct`
+	s.slog.Info("handling", "id", id)            // want `missing traceId in logging keys of s.slog, held by a struct`
+	s.deps.log.Info("handling", "id", id)        // want `missing traceId in logging keys of s.deps.log, held by a struct`
+	s.log.WithValues("traceId", id, "spanId", id).Info("handled")
+}
+
+func (s *Server) Traced(ctx context.Context) {
+	log := s.log.WithValues("traceId", "value", "spanId", "value")
+	log.Info("message")
+}
+
+func (s *Server) NoContext(id string) {
+	s.log.Info("handling", "id", id)
+}
+
+func (s Server) ValueReceiver(ctx context.Context) {
+	s.log.Info("message") // want `missing traceId in logging keys of s.log, held by a struct`
+}
+
+func Param(ctx context.Context, s *Server) {
+	s.log.Info("message") // want `missing traceId in logging keys of s.log, held by a struct`
+}
+
+func Local(ctx context.Context, base logr.Logger) {
+	s := &Server{log: base.WithValues("traceId", "value", "spanId", "value")}
+	s.log.Info("message")
+}
@@ -0,0 +1,55 @@
+package tracedlogger
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"a/tracedlogger/util"
+)
+
+func ExampleImported(ctx context.Context) {
+	util.NewRequestLogger(ctx).Info("message", "key", "value")
+	util.NewLogger(ctx).Info("message", "key", "value") // want `missing traceId in logging keys`
+
+	logger := util.NewRequestLogger(ctx)
+	logger.Info("message", "key", "value")
+	logger = util.NewLogger(ctx)
+	logger.Info("message", "key", "value") // want `missing traceId in logging keys`
+}
+
+func ExampleTracingFunc(ctx context.Context, logger logr.Logger) {
+	util.WithTrace(ctx, logger).Info("message", "key", "value")
+	logger.Info("message", "key", "value") // want `missing traceId in logging keys`
+}
+
+func ExampleZerolog(ctx context.Context) {
+	logger := util.NewEventLogger(ctx)
+	logger.Info().Str("key", "value").Msg("message")
+}
+
+func ExampleZap(ctx context.Context, base *zap.Logger) {
+	logger, err := util.WithZapTrace(ctx, base)
+	if err != nil {
+		return
+	}
+	logger.Info("message", zap.String("key", "value"))
+	base.Info("message", zap.String("key", "value")) // want `missing traceId in logging keys`
+}
+
+func newLocalLogger(ctx context.Context) logr.Logger {
+	span := trace.SpanFromContext(ctx)
+	logger := logr.FromContextOrDiscard(ctx)
+	return logger.WithValues("traceId", span.SpanContext().TraceID().String(), "spanId", span.SpanContext().SpanID().String())
+}
+
+func newWrappedLogger(ctx context.Context) logr.Logger {
+	return newLocalLogger(ctx)
+}
+
+func ExampleLocal(ctx context.Context) {
+	newLocalLogger(ctx).Info("message", "key", "value")
+	newWrappedLogger(ctx).Info("message", "key", "value")
+}
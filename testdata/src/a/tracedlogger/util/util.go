@@ -0,0 +1,44 @@
+package util
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// NewRequestLogger returns a logger carrying the trace keys.
+func NewRequestLogger(ctx context.Context) logr.Logger {
+	span := trace.SpanFromContext(ctx)
+	return logr.FromContextOrDiscard(ctx).WithValues("traceId", span.SpanContext().TraceID().String(), "spanId", span.SpanContext().SpanID().String())
+}
+
+// WithTrace adds the trace keys to logger.
+func WithTrace(ctx context.Context, logger logr.Logger) logr.Logger {
+	span := trace.SpanFromContext(ctx)
+	logger = logger.WithValues("traceId", span.SpanContext().TraceID().String(), "spanId", span.SpanContext().SpanID().String())
+	return logger
+}
+
+// NewEventLogger returns a zerolog logger carrying the trace keys.
+func NewEventLogger(ctx context.Context) zerolog.Logger {
+	span := trace.SpanFromContext(ctx)
+	return zerolog.Ctx(ctx).With().Str("traceId", span.SpanContext().TraceID().String()).Str("spanId", span.SpanContext().SpanID().String()).Logger()
+}
+
+// WithZapTrace adds the trace keys to logger, which must not be nil.
+func WithZapTrace(ctx context.Context, logger *zap.Logger) (*zap.Logger, error) {
+	if logger == nil {
+		return nil, errors.New("nil logger")
+	}
+	span := trace.SpanFromContext(ctx)
+	return logger.With(zap.String("traceId", span.SpanContext().TraceID().String()), zap.String("spanId", span.SpanContext().SpanID().String())), nil
+}
+
+// NewLogger returns a logger without the trace keys.
+func NewLogger(ctx context.Context) logr.Logger {
+	return logr.FromContextOrDiscard(ctx)
+}
@@ -0,0 +1,16 @@
+package traceflags
+
+import (
+	"context"
+
+	"github.com/go-logr/zapr"
+	"go.uber.org/zap"
+)
+
+func ExampleTraceFlags(ctx context.Context) {
+	zapr.NewLogger(zap.L()).WithValues("traceId", "value", "spanId", "value", "trace_flags", "value")
+	zapr.NewLogger(zap.L()).WithValues("traceId", "value", "spanId", "value", "sampled", true)
+	zapr.NewLogger(zap.L()).WithValues("traceId", "value", "spanId", "value") // want `missing trace flags in logging keys`
+	zapr.NewLogger(zap.L()).WithValues("traceId", "value")                    // want `missing spanId in logging keys` `missing trace flags in logging keys`
+	zapr.NewLogger(zap.L()).WithValues("key", "value")                        // want `missing traceId in logging keys`
+}
@@ -0,0 +1,83 @@
+package tracekey
+
+import (
+	"context"
+
+	"github.com/go-logr/zapr"
+	"go.uber.org/zap"
+
+	"a/tracekey/keys"
+)
+
+const traceKey = "traceId"
+
+var packageTraceKey = "traceId"
+
+type telemetry struct {
+	TraceLogKey string
+	RequestKey  string
+}
+
+func ExamplePackageConst(ctx context.Context) {
+	zapr.NewLogger(zap.L()).WithValues(traceKey, "value", "spanId", "value")
+}
+
+func ExampleOtherPackageConst(ctx context.Context) {
+	zapr.NewLogger(zap.L()).WithValues(keys.TraceID, "value", "spanId", "value")
+	zapr.NewLogger(zap.L()).WithValues(keys.Request, "value") // want `missing traceId in logging keys`
+}
+
+func ExamplePackageVar(ctx context.Context) {
+	zapr.NewLogger(zap.L()).WithValues(packageTraceKey, "value", "spanId", "value")
+}
+
+func ExampleMisleadingName(ctx context.Context) {
+	const traceKey = "request"
+	zapr.NewLogger(zap.L()).WithValues(traceKey, "value") // want `missing traceId in logging keys`
+}
+
+func ExampleLocalVar(ctx context.Context) {
+	key := "request"
+	key = "traceId"
+	zapr.NewLogger(zap.L()).WithValues(key, "value", "spanId", "value")
+
+	other := keys.Request
+	zapr.NewLogger(zap.L()).WithValues(other, "value") // want `missing traceId in logging keys`
+}
+
+func ExampleBranch(ctx context.Context, debug bool) {
+	key := "traceId"
+	if debug {
+		key = "msg"
+	}
+	zapr.NewLogger(zap.L()).WithValues(key, "value", "spanId", "value")
+}
+
+func ExampleBranchWithoutTraceKey(ctx context.Context, debug bool) {
+	key := "eventType"
+	if debug {
+		key = "other"
+	}
+	zapr.NewLogger(zap.L()).WithValues(key, "value") // want `missing traceId in logging keys`
+}
+
+func ExampleStructField(ctx context.Context) {
+	keyed := telemetry{TraceLogKey: keys.TraceID}
+	zapr.NewLogger(zap.L()).WithValues(keyed.TraceLogKey, "value", "spanId", "value")
+	zapr.NewLogger(zap.L()).WithValues(keyed.RequestKey, "value") // want `missing traceId in logging keys`
+
+	positional := &telemetry{"traceId", "request"}
+	zapr.NewLogger(zap.L()).WithValues(positional.TraceLogKey, "value", "spanId", "value")
+
+	var assigned telemetry
+	assigned.RequestKey = "traceId"
+	zapr.NewLogger(zap.L()).WithValues(assigned.RequestKey, "value", "spanId", "value")
+}
+
+func ExampleKeySpellings(ctx context.Context) {
+	zapr.NewLogger(zap.L()).WithValues("trace_id", "value", "spanId", "value")
+	zapr.NewLogger(zap.L()).WithValues("traceID", "value", "spanId", "value")
+	zapr.NewLogger(zap.L()).WithValues("logging.googleapis.com/trace", "value", "spanId", "value")
+	zapr.NewLogger(zap.L()).WithValues("stacktrace", "value") // want `missing traceId in logging keys`
+	zapr.NewLogger(zap.L()).WithValues("traceback", "value")  // want `missing traceId in logging keys`
+}
@@ -0,0 +1,6 @@
+package keys
+
+const (
+	TraceID = "traceId"
+	Request = "request"
+)
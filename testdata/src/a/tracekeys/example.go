@@ -0,0 +1,14 @@
+package tracekeys
+
+import (
+	"context"
+
+	"github.com/go-logr/zapr"
+	"go.uber.org/zap"
+)
+
+func ExampleCustomTraceKeys(ctx context.Context) {
+	zapr.NewLogger(zap.L()).WithValues("x-b3-traceid", "value", "spanId", "value")
+	zapr.NewLogger(zap.L()).WithValues("request_trace", "value", "spanId", "value")
+	zapr.NewLogger(zap.L()).WithValues("traceId", "value") // want `missing traceId in logging keys`
+}
@@ -0,0 +1,28 @@
+package constructor
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/zapr"
+	"go.uber.org/zap"
+
+	"a/tracepolicy/factory"
+)
+
+func ExampleConstructor(ctx context.Context) {
+	zapr.NewLogger(zap.L()).WithValues("key", "value") // want `missing traceId in logging keys`
+
+	logger := zapr.NewLogger(zap.L())
+	logger.WithValues("key", "value")      // want `missing traceId in logging keys`
+	logger.Info("message", "key", "value") // want `missing traceId in logging keys`
+}
+
+func ExampleCustomConstructor(ctx context.Context) {
+	factory.NewLogger("example").WithValues("key", "value") // want `missing traceId in logging keys`
+}
+
+func ExampleInjected(ctx context.Context, logger logr.Logger) {
+	logger.WithValues("key", "value")
+	logr.FromContextOrDiscard(ctx).WithValues("key", "value")
+}
@@ -0,0 +1,12 @@
+package factory
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/go-logr/zapr"
+	"go.uber.org/zap"
+)
+
+// NewLogger is an in-house logger constructor.
+func NewLogger(name string) logr.Logger {
+	return zapr.NewLogger(zap.L()).WithName(name)
+}
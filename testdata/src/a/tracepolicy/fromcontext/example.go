@@ -0,0 +1,24 @@
+package fromcontext
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/zapr"
+	"go.uber.org/zap"
+)
+
+func ExampleFromContext(ctx context.Context) {
+	logr.FromContextOrDiscard(ctx).WithValues("key", "value") // want `missing traceId in logging keys`
+
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return
+	}
+	logger.WithValues("key", "value")      // want `missing traceId in logging keys`
+	logger.Info("message", "key", "value") // want `missing traceId in logging keys`
+}
+
+func ExampleConstructor(ctx context.Context) {
+	zapr.NewLogger(zap.L()).WithValues("key", "value")
+}
@@ -0,0 +1,18 @@
+package logcall
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+)
+
+func ExampleLogCall(ctx context.Context, logger logr.Logger) {
+	logger.Info("message", "key", "value")                       // want `missing traceId in logging keys`
+	logger.Error(errors.New("error"), "message", "key", "value") // want `missing traceId in logging keys`
+	logger.WithValues("key", "value")
+}
+
+func ExampleNoContext(logger logr.Logger) {
+	logger.Info("message", "key", "value")
+}
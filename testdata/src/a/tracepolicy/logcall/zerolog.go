@@ -0,0 +1,19 @@
+package logcall
+
+import (
+	"context"
+	"os"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Chains are judged by their terminal method: Msg and Send are logging
+// calls whatever the logger, while the Logger method ending a With chain
+// is only checked by the withvalues policy.
+func ExampleChain(ctx context.Context) {
+	log.Info().Str("key", "value").Msg("message") // want `missing traceId in logging keys`
+	log.Error().Str("key", "value").Send()        // want `missing traceId in logging keys`
+	logger := zerolog.New(os.Stdout).With().Str("key", "value").Logger()
+	logger.Info().Str("traceId", "value").Str("spanId", "value").Msg("message")
+}
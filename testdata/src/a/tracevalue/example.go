@@ -0,0 +1,65 @@
+package tracevalue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/zapr"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"a/tracevalue/tracing"
+)
+
+func ExampleSpanFromContext(ctx context.Context) {
+	span := trace.SpanFromContext(ctx)
+	zapr.NewLogger(zap.L()).WithValues("traceId", span.SpanContext().TraceID().String(), "spanId", "value")
+	zapr.NewLogger(zap.L()).WithValues("traceId", trace.SpanContextFromContext(ctx).TraceID().String(), "spanId", "value")
+}
+
+func ExampleTracerStart(ctx context.Context, tracer trace.Tracer) {
+	ctx, span := tracer.Start(ctx, "example")
+	defer span.End()
+
+	traceID := span.SpanContext().TraceID()
+	zapr.NewLogger(zap.L()).WithValues("traceId", traceID, "spanId", "value")
+}
+
+func ExampleDerivedContext(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	zapr.NewLogger(zap.L()).WithValues("traceId", trace.SpanFromContext(ctx).SpanContext().TraceID().String(), "spanId", "value")
+}
+
+func ExampleClosure(ctx context.Context) {
+	span := trace.SpanFromContext(ctx)
+	func(ctx context.Context) {
+		zapr.NewLogger(zap.L()).WithValues("traceId", span.SpanContext().TraceID().String(), "spanId", "value")
+	}(context.Background())
+}
+
+func ExampleBranch(ctx context.Context, remote bool) {
+	traceID := trace.SpanFromContext(ctx).SpanContext().TraceID().String()
+	if remote {
+		traceID = "remote"
+	}
+	zapr.NewLogger(zap.L()).WithValues("traceId", traceID, "spanId", "value") // want `traceId value is a constant, not the trace id of the span in ctx`
+}
+
+func ExampleLiteral(ctx context.Context) {
+	zapr.NewLogger(zap.L()).WithValues("traceId", "4bf92f3577b34da6a3ce929d0e0e4736", "spanId", "value") // want `traceId value is a constant, not the trace id of the span in ctx`
+}
+
+func ExampleOtherContext(ctx context.Context) {
+	span := trace.SpanFromContext(context.Background())
+	zapr.NewLogger(zap.L()).WithValues("traceId", span.SpanContext().TraceID().String(), "spanId", "value") // want `traceId value is taken from a context other than the function's ctx`
+}
+
+func ExampleUnrelatedValue(ctx context.Context, requestID int) {
+	zapr.NewLogger(zap.L()).WithValues("traceId", fmt.Sprint(requestID), "spanId", "value") // want `traceId value is not derived from trace.SpanFromContext\(ctx\)`
+}
+
+func ExampleHelper(ctx context.Context) {
+	zapr.NewLogger(zap.L()).WithValues("traceId", tracing.TraceID(ctx), "spanId", "value")
+	zapr.NewLogger(zap.L()).WithValues("traceId", tracing.TraceID(context.TODO()), "spanId", "value") // want `traceId value is taken from a context other than the function's ctx`
+}
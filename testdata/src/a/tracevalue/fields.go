@@ -0,0 +1,17 @@
+package tracevalue
+
+import (
+	"context"
+	"os"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func ExampleChainField(ctx context.Context) {
+	span := trace.SpanFromContext(ctx)
+	logger := zerolog.New(os.Stdout)
+	logger.Info().Str("traceId", span.SpanContext().TraceID().String()).Str("spanId", "value").Msg("message")
+	logger.Info().Str("traceId", "value").Str("spanId", "value").Msg("message")                                                                      // want `traceId value is a constant, not the trace id of the span in ctx`
+	logger.Info().Str("traceId", trace.SpanFromContext(context.Background()).SpanContext().TraceID().String()).Str("spanId", "value").Msg("message") // want `traceId value is taken from a context other than the function's ctx`
+}
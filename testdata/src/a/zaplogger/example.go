@@ -0,0 +1,34 @@
+package zaplogger
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"a/zaplogger/fields"
+)
+
+func ExampleFields(ctx context.Context, logger *zap.Logger, id fmt.Stringer) {
+	logger.Info("message", zap.String("key", "value")) // want `missing traceId in logging keys`
+	logger.Info("message", zap.String("traceId", "value"), zap.String("spanId", "value"))
+	logger.Info("message", zap.Stringer("traceId", id), zap.Any("spanId", id))
+	logger.Error("message", zap.String("traceId", "value"))                                    // want `missing spanId in logging keys`
+	logger.Log(zapcore.InfoLevel, "message", zap.Int("count", 1))                              // want `missing traceId in logging keys`
+	logger.With(zap.String("traceId", "value")).Info("message", zap.String("spanId", "value")) // want `missing spanId in logging keys` `missing traceId in logging keys`
+}
+
+func ExampleSugar(ctx context.Context, logger *zap.Logger) {
+	logger.Sugar().Infow("message", zap.String("traceId", "value"), "spanId", "value")
+	logger.Sugar().Infow("message", "key", "value", zap.Any("traceId", "value")) // want `missing spanId in logging keys`
+}
+
+// A field of another package named Field is a key/value argument.
+func ExampleOtherField(ctx context.Context, logger *zap.Logger) {
+	logger.Sugar().Infow("message", fields.Field{}, "traceId", "value", "spanId", "value") // want `odd number of arguments passed as key-value pairs for logging` `missing traceId in logging keys`
+}
+
+func ExampleNoContext(logger *zap.Logger) {
+	logger.Info("message", zap.String("key", "value"))
+}
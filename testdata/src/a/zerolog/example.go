@@ -0,0 +1,55 @@
+package zerolog
+
+import (
+	"context"
+	"os"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+func ExampleEvent(ctx context.Context) {
+	logger := zerolog.New(os.Stdout)
+	logger.Info().Str("key", "value").Msg("message") // want `missing traceId in logging keys`
+	logger.Info().Str("traceId", "value").Str("spanId", "value").Msg("message")
+	logger.Error().Err(os.ErrClosed).Str("traceId", "value").Int("spanId", 1).Send()
+	logger.Warn().Str("traceId", "value").Msgf("message %d", 1)                                                  // want `missing spanId in logging keys`
+	logger.Debug().Dict("request", zerolog.Dict().Str("traceId", "value").Str("spanId", "value")).Msg("message") // want `missing traceId in logging keys`
+
+	zerolog.Ctx(ctx).Info().Msg("message") // want `missing traceId in logging keys`
+
+	// Only loggers returned by a constructor or taken from the context are checked.
+	log.Info().Msg("message")
+}
+
+func ExampleContext(ctx context.Context) {
+	logger := zerolog.New(os.Stdout).With().Str("key", "value").Logger() // want `missing traceId in logging keys`
+	logger = logger.With().Str("traceId", "value").Str("spanId", "value").Logger()
+	logger.Info().Msg("message")
+}
+
+func ExampleVariable(ctx context.Context) {
+	logger := zerolog.New(os.Stdout)
+	event := logger.Info().Str("traceId", "value")
+	event.Msg("message")
+}
+
+func ExampleKeys(ctx context.Context, key string) {
+	logger := zerolog.New(os.Stdout)
+	logger.Info().Str("traceId", "value").Str("spanId", "value").Str(key, "value").Msg("message") // want `logging keys are expected to be inlined constant strings, please replace "key" provided with string`
+	logger.Info().Str("traceId", "value").Str("spanId", "value").Msg("100%d done")                // want `logging message should not use format specifier "%d"`
+}
+
+func ExampleNotSent(ctx context.Context) {
+	logger := zerolog.New(os.Stdout)
+	logger.Info().Str("traceId", "value").Str("spanId", "value") // want `logging event is never sent`
+	log.Info()                                                   // want `logging event is never sent`
+	logger.With().Str("key", "value")
+	event := logger.Info()
+	event.Send()
+}
+
+func ExampleNoContext() {
+	logger := zerolog.New(os.Stdout)
+	logger.Info().Msg("message")
+}